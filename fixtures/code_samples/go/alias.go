@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// keyAliases maps deprecated keys, lower-cased, to their canonical key.
+var keyAliases = struct {
+	sync.RWMutex
+	byOld map[string]string
+}{byOld: make(map[string]string)}
+
+// RegisterAlias makes loaders accept oldKey as a deprecated spelling of
+// newKey, which should be a canonical key such as "value". Keys match
+// case-insensitively. When a document has both, the canonical key wins.
+// Either way the loaded config's Warnings record the deprecated key.
+//
+// Aliases apply to FromMap, LoadYAML, LoadTOML and JSON decoding.
+func RegisterAlias(oldKey, newKey string) {
+	keyAliases.Lock()
+	defer keyAliases.Unlock()
+	keyAliases.byOld[strings.ToLower(oldKey)] = strings.ToLower(newKey)
+}
+
+// ClearAliases removes every registered alias.
+func ClearAliases() {
+	keyAliases.Lock()
+	defer keyAliases.Unlock()
+	clear(keyAliases.byOld)
+}
+
+// Warnings lists the deprecated keys encountered while loading c.
+func (c *Config) Warnings() []string {
+	return slices.Clone(c.warnings)
+}
+
+// canonicalizeKeys renames aliased keys of m to their canonical key,
+// returning a new map and a warning per deprecated key seen, sorted by key.
+func canonicalizeKeys[V any](m map[string]V) (map[string]V, []string) {
+	keyAliases.RLock()
+	defer keyAliases.RUnlock()
+	if len(keyAliases.byOld) == 0 {
+		return m, nil
+	}
+	present := make(map[string]bool, len(m))
+	for key := range m {
+		present[strings.ToLower(key)] = true
+	}
+	out := make(map[string]V, len(m))
+	var warnings []string
+	// Visit keys in order so that, of several aliases for one key, the
+	// first in sort order wins every time.
+	for _, key := range slices.Sorted(maps.Keys(m)) {
+		canonical, ok := keyAliases.byOld[strings.ToLower(key)]
+		if !ok {
+			out[key] = m[key]
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf("key %q is deprecated, use %q", key, canonical))
+		if _, taken := out[canonical]; !taken && !present[canonical] {
+			out[canonical] = m[key]
+		}
+	}
+	return out, warnings
+}
+
+// canonicalizeJSON applies canonicalizeKeys to a JSON object's keys.
+func canonicalizeJSON(data []byte) ([]byte, []string, error) {
+	keyAliases.RLock()
+	none := len(keyAliases.byOld) == 0
+	keyAliases.RUnlock()
+	if none {
+		return data, nil, nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, nil, err
+	}
+	fields, warnings := canonicalizeKeys(fields)
+	if warnings == nil {
+		return data, nil, nil
+	}
+	data, err := json.Marshal(fields)
+	return data, warnings, err
+}
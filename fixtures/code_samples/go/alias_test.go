@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestAliasOldKeyOnly(t *testing.T) {
+	RegisterAlias("val", "value")
+	t.Cleanup(ClearAliases)
+
+	load := map[string]func() (*Config, error){
+		"FromMap": func() (*Config, error) {
+			return FromMap(map[string]any{"name": "db", "VAL": int64(5432)})
+		},
+		"JSON": func() (*Config, error) {
+			var cfg Config
+			err := json.Unmarshal([]byte(`{"name":"db","val":5432}`), &cfg)
+			return &cfg, err
+		},
+		"YAML": func() (*Config, error) {
+			return LoadYAML(strings.NewReader("name: db\nval: 5432\n"))
+		},
+		"TOML": func() (*Config, error) {
+			return LoadTOML(strings.NewReader("name = \"db\"\nval = 5432\n"))
+		},
+	}
+	for format, fn := range load {
+		cfg, err := fn()
+		if err != nil {
+			t.Errorf("%s: %v", format, err)
+			continue
+		}
+		if cfg.Value != int64(5432) {
+			t.Errorf("%s: Value = %v, want 5432 from the deprecated key", format, cfg.Value)
+		}
+		if w := cfg.Warnings(); len(w) != 1 || !strings.Contains(w[0], "deprecated") {
+			t.Errorf("%s: Warnings = %q, want one deprecation warning", format, w)
+		}
+	}
+}
+
+func TestAliasCanonicalKeyWins(t *testing.T) {
+	RegisterAlias("val", "value")
+	t.Cleanup(ClearAliases)
+
+	cfg, err := FromMap(map[string]any{"name": "db", "val": int64(1), "value": int64(2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Value != int64(2) {
+		t.Errorf("Value = %v, want 2 from the canonical key", cfg.Value)
+	}
+	if len(cfg.Warnings()) != 1 {
+		t.Errorf("Warnings = %q, want one", cfg.Warnings())
+	}
+}
+
+func TestClearAliases(t *testing.T) {
+	RegisterAlias("val", "value")
+	ClearAliases()
+
+	cfg, err := FromMap(map[string]any{"name": "db", "val": int64(5432)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Value != int64(0) || len(cfg.Warnings()) != 0 {
+		t.Errorf("after ClearAliases: Value %v, Warnings %q, want 0 and none", cfg.Value, cfg.Warnings())
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// Number is satisfied by the built-in integer and floating-point types.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Double returns x * 2. Like any int multiplication it wraps on overflow.
+func Double(x int) int {
+	return Scale(x, 2)
+}
+
+// Scale returns x * factor.
+func Scale[T Number](x, factor T) T {
+	return x * factor
+}
+
+// Scale returns a new Config with the int64 Value multiplied by factor.
+// It fails instead of wrapping around when the product overflows int64,
+// and leaves c untouched either way.
+func (c *Config) Scale(factor int64) (*Config, error) {
+	v, err := c.int64Value()
+	if err != nil {
+		return nil, err
+	}
+	product, ok := mulInt64(v, factor)
+	if !ok {
+		return nil, fmt.Errorf("%w for %q: scaling %d by %d overflows int64", ErrInvalidValue, c.Name, v, factor)
+	}
+	out := c.Clone()
+	out.Value = product
+	return out, nil
+}
+
+// mulInt64 returns a * b and whether the product fits in an int64.
+func mulInt64(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	if (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, false
+	}
+	product := a * b
+	return product, product/b == a
+}
+
+// Clamp returns a new Config with the int64 Value constrained to
+// [min, max]. Values of other types are copied unchanged. Clamp panics if
+// min > max, as that is a programming error rather than bad input.
+func (c *Config) Clamp(min, max int64) *Config {
+	if min > max {
+		panic(fmt.Sprintf("config: Clamp called with min %d > max %d", min, max))
+	}
+	out := c.Clone()
+	if v, ok := c.AsInt64(); ok {
+		out.Value = v
+		if v < min {
+			out.Value = min
+		} else if v > max {
+			out.Value = max
+		}
+	}
+	return out
+}
+
+// NewConfigClamped creates a Config with value constrained to [min, max].
+// Like Clamp, it panics if min > max.
+func NewConfigClamped(name string, value, min, max int64) *Config {
+	return NewConfig(name, WithValue(value)).Clamp(min, max)
+}
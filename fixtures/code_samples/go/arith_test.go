@@ -0,0 +1,129 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestDouble(t *testing.T) {
+	tests := []struct {
+		x, want int
+	}{
+		{0, 0},
+		{1, 2},
+		{-1, -2},
+		{-21, -42},
+		{math.MaxInt / 2, math.MaxInt - 1},
+		{math.MinInt / 2, math.MinInt},
+		{math.MaxInt/2 + 1, math.MinInt}, // documented wrap-around
+	}
+	for _, tt := range tests {
+		if got := Double(tt.x); got != tt.want {
+			t.Errorf("Double(%d) = %d, want %d", tt.x, got, tt.want)
+		}
+		if got := helper(tt.x); got != tt.want {
+			t.Errorf("helper(%d) = %d, want %d", tt.x, got, tt.want)
+		}
+	}
+}
+
+func TestScaleGeneric(t *testing.T) {
+	if got := Scale(int64(-3), 4); got != -12 {
+		t.Errorf("Scale(int64(-3), 4) = %d, want -12", got)
+	}
+	if got := Scale(uint8(100), 2); got != 200 {
+		t.Errorf("Scale(uint8(100), 2) = %d, want 200", got)
+	}
+	if got := Scale(1.5, 0.5); got != 0.75 {
+		t.Errorf("Scale(1.5, 0.5) = %v, want 0.75", got)
+	}
+}
+
+func TestConfigScaleBoundaries(t *testing.T) {
+	tests := []struct {
+		value, factor int64
+		want          int64
+		overflow      bool
+	}{
+		{math.MaxInt64, 1, math.MaxInt64, false},
+		{math.MaxInt64, -1, -math.MaxInt64, false},
+		{math.MaxInt64, 2, 0, true},
+		{math.MaxInt64 / 3, 3, math.MaxInt64 - 1, false},
+		{math.MaxInt64/3 + 1, 3, 0, true},
+		{math.MinInt64, 1, math.MinInt64, false},
+		{math.MinInt64, -1, 0, true},
+		{math.MinInt64, 2, 0, true},
+		{math.MinInt64 / 2, 2, math.MinInt64, false},
+		{-1, math.MinInt64, 0, true},
+		{1, math.MinInt64, math.MinInt64, false},
+		{math.MinInt64, 0, 0, false},
+		{0, math.MaxInt64, 0, false},
+		{9e18, 3, 0, true},
+	}
+	for _, tt := range tests {
+		orig := NewConfig("n", WithValue(tt.value))
+		got, err := orig.Scale(tt.factor)
+		if orig.Value != tt.value {
+			t.Errorf("Scale modified the receiver to %v", orig.Value)
+		}
+		if tt.overflow {
+			if !errors.Is(err, ErrInvalidValue) {
+				t.Errorf("Scale(%d * %d) = %v, %v; want an overflow error", tt.value, tt.factor, got, err)
+			}
+			continue
+		}
+		if err != nil || got.Value != tt.want {
+			t.Errorf("Scale(%d * %d) = %v, %v; want %d", tt.value, tt.factor, got, err, tt.want)
+		}
+	}
+}
+
+func TestConfigScaleNonInt64(t *testing.T) {
+	if _, err := NewStringConfig("s", "x").Scale(2); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("Scale of a string config = %v, want ErrInvalidValue", err)
+	}
+}
+
+func TestClamp(t *testing.T) {
+	tests := []struct {
+		value, min, max, want int64
+	}{
+		{-5, 0, 10, 0},
+		{0, 0, 10, 0},
+		{5, 0, 10, 5},
+		{10, 0, 10, 10},
+		{15, 0, 10, 10},
+		{-5, 3, 3, 3},
+		{3, 3, 3, 3},
+		{9, 3, 3, 3},
+		{math.MinInt64, math.MinInt64, math.MaxInt64, math.MinInt64},
+	}
+	for _, tt := range tests {
+		orig := NewConfig("n", WithValue(tt.value))
+		if got := orig.Clamp(tt.min, tt.max); got.Value != tt.want {
+			t.Errorf("Clamp(%d, %d) of %d = %v, want %d", tt.min, tt.max, tt.value, got.Value, tt.want)
+		}
+		if orig.Value != tt.value {
+			t.Errorf("Clamp modified the receiver to %v", orig.Value)
+		}
+		if got := NewConfigClamped("n", tt.value, tt.min, tt.max); got.Value != tt.want {
+			t.Errorf("NewConfigClamped(%d, %d, %d) = %v, want %d", tt.value, tt.min, tt.max, got.Value, tt.want)
+		}
+	}
+}
+
+func TestClampNonInt64Unchanged(t *testing.T) {
+	if got := NewStringConfig("s", "x").Clamp(0, 1); got.Value != "x" {
+		t.Errorf("Clamp of a string config = %v, want it unchanged", got.Value)
+	}
+}
+
+func TestClampPanicsOnInvertedRange(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Clamp(10, 0) did not panic")
+		}
+	}()
+	NewConfig("n", WithValue(5)).Clamp(10, 0)
+}
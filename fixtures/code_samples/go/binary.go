@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Value type tags of the binary encoding.
+const (
+	binaryInt64 byte = iota
+	binaryString
+	binaryBool
+	binaryFloat64
+)
+
+// MarshalBinary encodes the config compactly. The layout, in order:
+//
+//	name        uvarint length, then UTF-8 bytes
+//	value tag   1 byte: 0 int64, 1 string, 2 bool, 3 float64
+//	value       int64:   zig-zag varint
+//	            string:  uvarint length, then bytes
+//	            bool:    1 byte, 0 or 1
+//	            float64: 8 bytes, IEEE 754 bits big-endian
+//	description uvarint length, then UTF-8 bytes
+//
+// Varints are byte-oriented, so the format does not depend on the host's
+// endianness. A nil Value is written as int64 zero.
+func (c *Config) MarshalBinary() ([]byte, error) {
+	buf := binary.AppendUvarint(nil, uint64(len(c.Name)))
+	buf = append(buf, c.Name...)
+	switch v := c.Value.(type) {
+	case nil:
+		buf = binary.AppendVarint(append(buf, binaryInt64), 0)
+	case int64:
+		buf = binary.AppendVarint(append(buf, binaryInt64), v)
+	case string:
+		buf = binary.AppendUvarint(append(buf, binaryString), uint64(len(v)))
+		buf = append(buf, v...)
+	case bool:
+		b := byte(0)
+		if v {
+			b = 1
+		}
+		buf = append(buf, binaryBool, b)
+	case float64:
+		buf = binary.BigEndian.AppendUint64(append(buf, binaryFloat64), math.Float64bits(v))
+	default:
+		return nil, fmt.Errorf("%w for %q: unsupported type %T", ErrInvalidValue, c.Name, v)
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(c.Description)))
+	return append(buf, c.Description...), nil
+}
+
+// UnmarshalBinary decodes the layout written by MarshalBinary, rejecting
+// truncated input and trailing bytes.
+func (c *Config) UnmarshalBinary(data []byte) error {
+	d := binaryDecoder{data: data}
+	name := d.string()
+	var value any
+	switch tag := d.byte(); tag {
+	case binaryInt64:
+		value = d.varint()
+	case binaryString:
+		value = d.string()
+	case binaryBool:
+		switch b := d.byte(); b {
+		case 0, 1:
+			value = b == 1
+		default:
+			d.fail(fmt.Errorf("invalid bool byte %d", b))
+		}
+	case binaryFloat64:
+		value = math.Float64frombits(binary.BigEndian.Uint64(d.next(8)))
+	default:
+		d.fail(fmt.Errorf("unknown value tag %d", tag))
+	}
+	description := d.string()
+	if d.err == nil && len(d.data) > 0 {
+		d.fail(fmt.Errorf("%d trailing bytes", len(d.data)))
+	}
+	if d.err != nil {
+		return fmt.Errorf("config: decoding binary: %w", d.err)
+	}
+	c.Name, c.Value, c.Description = name, value, description
+	c.present = []string{"Name", "Value", "Description"}
+	return nil
+}
+
+// binaryDecoder consumes data, recording the first error; reads after an
+// error return zero values.
+type binaryDecoder struct {
+	data []byte
+	err  error
+}
+
+var errTruncated = errors.New("truncated input")
+
+func (d *binaryDecoder) fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+	d.data = nil
+}
+
+func (d *binaryDecoder) next(n int) []byte {
+	if d.err != nil || n > len(d.data) {
+		d.fail(errTruncated)
+		return make([]byte, n)
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b
+}
+
+func (d *binaryDecoder) byte() byte {
+	return d.next(1)[0]
+}
+
+func (d *binaryDecoder) varint() int64 {
+	v, n := binary.Varint(d.data)
+	if n <= 0 {
+		d.fail(errTruncated)
+		return 0
+	}
+	d.data = d.data[n:]
+	return v
+}
+
+func (d *binaryDecoder) string() string {
+	n, size := binary.Uvarint(d.data)
+	if size <= 0 || n > uint64(len(d.data)-size) {
+		d.fail(errTruncated)
+		return ""
+	}
+	d.data = d.data[size:]
+	return string(d.next(int(n)))
+}
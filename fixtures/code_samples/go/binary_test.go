@@ -0,0 +1,66 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func FuzzBinaryRoundTrip(f *testing.F) {
+	f.Add("port", byte(binaryInt64), int64(8080), "", false, 0.0, "")
+	f.Add("", byte(binaryInt64), int64(math.MinInt64), "", false, 0.0, "")
+	f.Add("host", byte(binaryString), int64(0), "localhost", false, 0.0, "primary")
+	f.Add("héllo\x00", byte(binaryString), int64(0), "\xff\xfe", false, 0.0, "\n")
+	f.Add("debug", byte(binaryBool), int64(0), "", true, 0.0, "")
+	f.Add("ratio", byte(binaryFloat64), int64(0), "", false, math.NaN(), "")
+	f.Add("ratio", byte(binaryFloat64), int64(0), "", false, math.Inf(-1), "")
+	f.Fuzz(func(t *testing.T, name string, kind byte, i int64, s string, b bool, fl float64, desc string) {
+		var value any
+		switch kind % 4 {
+		case binaryInt64:
+			value = i
+		case binaryString:
+			value = s
+		case binaryBool:
+			value = b
+		case binaryFloat64:
+			value = fl
+		}
+		want := &Config{Name: name, Value: value, Description: desc}
+		data, err := want.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary(%#v): %v", want, err)
+		}
+		var got Config
+		if err := got.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary(%x): %v", data, err)
+		}
+		if f, ok := value.(float64); ok {
+			// NaN != NaN, so compare the bits the encoding preserves.
+			g, _ := got.AsFloat64()
+			if math.Float64bits(g) != math.Float64bits(f) {
+				t.Errorf("float round trip: got %v, want %v", g, f)
+			}
+			got.Value, want.Value = nil, nil
+		}
+		if !got.Equal(want) {
+			t.Errorf("round trip of %#v = %#v", want, &got)
+		}
+	})
+}
+
+func TestUnmarshalBinaryRejectsCorruptInput(t *testing.T) {
+	data, err := NewConfig("port", WithValue(8080), WithDescription("listen")).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for n := range len(data) {
+		var cfg Config
+		if err := cfg.UnmarshalBinary(data[:n]); err == nil {
+			t.Errorf("UnmarshalBinary of %d of %d bytes succeeded", n, len(data))
+		}
+	}
+	var cfg Config
+	if err := cfg.UnmarshalBinary(append(data, 0)); err == nil {
+		t.Error("UnmarshalBinary with a trailing byte succeeded")
+	}
+}
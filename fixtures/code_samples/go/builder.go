@@ -0,0 +1,49 @@
+package main
+
+// ConfigBuilder assembles a Config through chained calls. A builder can be
+// reused: every Build returns a fresh Config, and later changes to the
+// builder do not affect configs already built.
+type ConfigBuilder struct {
+	name string
+	opts []Option
+}
+
+// NewBuilder returns an empty ConfigBuilder.
+func NewBuilder() *ConfigBuilder {
+	return &ConfigBuilder{}
+}
+
+// Name sets the config name.
+func (b *ConfigBuilder) Name(name string) *ConfigBuilder {
+	b.name = name
+	return b
+}
+
+// Value sets an int64 value.
+func (b *ConfigBuilder) Value(value int64) *ConfigBuilder {
+	b.opts = append(b.opts, WithValue(value))
+	return b
+}
+
+// Description sets the description.
+func (b *ConfigBuilder) Description(description string) *ConfigBuilder {
+	b.opts = append(b.opts, WithDescription(description))
+	return b
+}
+
+// Validators attaches validators that Build, and later Validate calls on
+// the built config, will run.
+func (b *ConfigBuilder) Validators(validators ...ConfigValidator) *ConfigBuilder {
+	b.opts = append(b.opts, WithValidators(validators...))
+	return b
+}
+
+// Build creates the Config and validates it, returning an error rather
+// than a half-formed config when a rule fails.
+func (b *ConfigBuilder) Build() (*Config, error) {
+	cfg := NewConfig(b.name, b.opts...)
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuilderBuild(t *testing.T) {
+	cfg, err := NewBuilder().Name("db").Value(5432).Description("primary").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := NewConfig("db", WithValue(5432), WithDescription("primary")); !cfg.Equal(want) {
+		t.Errorf("Build() = %v, want %v", cfg, want)
+	}
+}
+
+func TestBuilderReuse(t *testing.T) {
+	b := NewBuilder().Name("db").Value(5432)
+	first, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Name("cache").Value(6379)
+	second, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !first.Equal(NewConfig("db", WithValue(5432))) {
+		t.Errorf("changing the builder altered the earlier config to %v", first)
+	}
+	if !second.Equal(NewConfig("cache", WithValue(6379))) {
+		t.Errorf("second Build() = %v", second)
+	}
+	if first == second {
+		t.Error("Build returned the same Config twice")
+	}
+}
+
+func TestBuilderMissingName(t *testing.T) {
+	cfg, err := NewBuilder().Value(1).Build()
+	if !errors.Is(err, ErrEmptyName) || cfg != nil {
+		t.Errorf("Build() without a name = %v, %v; want nil and ErrEmptyName", cfg, err)
+	}
+}
+
+func TestBuilderValidators(t *testing.T) {
+	b := NewBuilder().Name("pct").Value(101).Validators(InRange(0, 100))
+	if _, err := b.Build(); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("Build() = %v, want the validator's ErrInvalidValue", err)
+	}
+	cfg, err := b.Value(50).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Value = int64(200)
+	if err := cfg.Validate(); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("built config did not keep its validators: %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ReadCSV reads configs from CSV with a header row naming at least the
+// name and value columns, in any order; a description column is read if
+// present and other columns are ignored. An empty value cell means 0.
+// Errors name the 1-based row, counting the header as row 1.
+func ReadCSV(r io.Reader) ([]*Config, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("csv: missing header row")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("csv: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, title := range header {
+		columns[strings.ToLower(strings.TrimSpace(title))] = i
+	}
+	nameCol, hasName := columns["name"]
+	valueCol, hasValue := columns["value"]
+	descCol, hasDesc := columns["description"]
+	if !hasName || !hasValue {
+		return nil, fmt.Errorf("csv: header %q must have name and value columns", header)
+	}
+
+	var cfgs []*Config
+	for row := 2; ; row++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return cfgs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("csv: row %d: %w", row, err)
+		}
+		cfg := &Config{Name: record[nameCol], Value: int64(0), present: []string{"Name"}}
+		if cell := strings.TrimSpace(record[valueCol]); cell != "" {
+			v, err := strconv.ParseInt(cell, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("csv: row %d: %w", row, &ParseError{Field: "Value", Raw: record[valueCol], Err: err})
+			}
+			cfg.Value = v
+			cfg.markPresent("Value")
+		}
+		if hasDesc {
+			cfg.Description = record[descCol]
+			cfg.markPresent("Description")
+		}
+		cfgs = append(cfgs, cfg)
+	}
+}
+
+// WriteCSV writes cfgs with a name,value header, adding a description
+// column only when some config has a Description. Every Value must be an
+// int64.
+func WriteCSV(w io.Writer, cfgs []*Config) error {
+	withDesc := slices.ContainsFunc(cfgs, func(c *Config) bool { return c.Description != "" })
+	header := []string{"name", "value"}
+	if withDesc {
+		header = append(header, "description")
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, c := range cfgs {
+		value, err := c.int64Value()
+		if err != nil {
+			return fmt.Errorf("csv: %w", err)
+		}
+		record := []string{c.Name, strconv.FormatInt(value, 10)}
+		if withDesc {
+			record = append(record, c.Description)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCSVRoundTrip(t *testing.T) {
+	cfgs := []*Config{
+		NewConfig("db", WithValue(5432), WithDescription("primary, with a comma")),
+		NewConfig("cache", WithValue(-1)),
+		NewConfig(`quoted "name"`, WithValue(0), WithDescription("line\nbreak")),
+	}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, cfgs); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadCSV(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.EqualFunc(got, cfgs, (*Config).Equal) {
+		t.Errorf("round trip = %v, want %v", got, cfgs)
+	}
+}
+
+func TestReadCSVColumnOrder(t *testing.T) {
+	input := "Value,extra,Name\n42,ignored,answer\n,x,empty\n"
+	got, err := ReadCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Config{NewConfig("answer", WithValue(42)), NewConfig("empty")}
+	if !slices.EqualFunc(got, want, (*Config).Equal) {
+		t.Errorf("ReadCSV = %v, want %v", got, want)
+	}
+}
+
+func TestReadCSVBadRow(t *testing.T) {
+	input := "name,value\ndb,5432\ncache,lots\n"
+	_, err := ReadCSV(strings.NewReader(input))
+	if err == nil {
+		t.Fatal("ReadCSV succeeded, want an error")
+	}
+	if !strings.Contains(err.Error(), "row 3") {
+		t.Errorf("error %q does not name row 3", err)
+	}
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Raw != "lots" {
+		t.Errorf("error %v does not wrap a ParseError for %q", err, "lots")
+	}
+}
+
+func TestReadCSVMissingColumns(t *testing.T) {
+	for _, input := range []string{"", "name,description\n"} {
+		if _, err := ReadCSV(strings.NewReader(input)); err == nil {
+			t.Errorf("ReadCSV(%q) succeeded, want an error", input)
+		}
+	}
+}
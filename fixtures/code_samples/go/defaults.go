@@ -0,0 +1,31 @@
+package main
+
+import "sync"
+
+// defaultValues is the registry consulted by NewConfig.
+var defaultValues = struct {
+	sync.RWMutex
+	byName map[string]int64
+}{byName: make(map[string]int64)}
+
+// SetDefaults registers the default Value for configs called name. It is
+// safe for concurrent use.
+func SetDefaults(name string, value int64) {
+	defaultValues.Lock()
+	defer defaultValues.Unlock()
+	defaultValues.byName[name] = value
+}
+
+// ClearDefaults removes every registered default.
+func ClearDefaults() {
+	defaultValues.Lock()
+	defer defaultValues.Unlock()
+	clear(defaultValues.byName)
+}
+
+// defaultValue returns the registered default for name, or 0.
+func defaultValue(name string) int64 {
+	defaultValues.RLock()
+	defer defaultValues.RUnlock()
+	return defaultValues.byName[name]
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"errors"
+	"sync"
+)
+
+// derivedCache memoizes Derived results for one Config instance.
+type derivedCache struct {
+	mu      sync.Mutex
+	entries map[string]*derivedEntry
+}
+
+// derivedEntry is one computation; done is closed once value and err are
+// set.
+type derivedEntry struct {
+	hash  string
+	done  chan struct{}
+	value any
+	err   error
+}
+
+// derivedInit guards the lazy creation of Config.derived.
+var derivedInit sync.Mutex
+
+// Derived returns compute(c) memoized under key for this Config instance.
+// The cached value is tied to c.Hash(), so it is recomputed after any
+// field changes. Concurrent callers asking for the same key share a single
+// computation. Errors are returned to every waiting caller but not
+// cached, so the next call retries.
+//
+// Clones start with an empty cache.
+func (c *Config) Derived(key string, compute func(*Config) (any, error)) (any, error) {
+	hash := c.Hash()
+	cache := c.derivedCache()
+
+	cache.mu.Lock()
+	if e, ok := cache.entries[key]; ok && e.hash == hash {
+		cache.mu.Unlock()
+		<-e.done
+		return e.value, e.err
+	}
+	e := &derivedEntry{hash: hash, done: make(chan struct{})}
+	cache.entries[key] = e
+	cache.mu.Unlock()
+
+	defer func() {
+		if e.err != nil {
+			cache.mu.Lock()
+			if cache.entries[key] == e {
+				delete(cache.entries, key)
+			}
+			cache.mu.Unlock()
+		}
+		close(e.done)
+	}()
+	e.err = errDerivedPanic
+	e.value, e.err = compute(c)
+	return e.value, e.err
+}
+
+// errDerivedPanic is what callers waiting on a Derived computation see if
+// compute panics.
+var errDerivedPanic = errors.New("config: derived value computation panicked")
+
+// derivedCache returns c's cache, creating it on first use.
+func (c *Config) derivedCache() *derivedCache {
+	derivedInit.Lock()
+	defer derivedInit.Unlock()
+	if c.derived == nil {
+		c.derived = &derivedCache{entries: make(map[string]*derivedEntry)}
+	}
+	return c.derived
+}
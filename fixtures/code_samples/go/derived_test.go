@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDerivedComputesOnceConcurrently(t *testing.T) {
+	cfg := NewConfig("db", WithValue(5432))
+	var calls atomic.Int32
+	compute := func(c *Config) (any, error) {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return c.Name + ":" + c.String(), nil
+	}
+
+	var wg sync.WaitGroup
+	for range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := cfg.Derived("dsn", compute); err != nil || v != "db:db: 5432" {
+				t.Errorf("Derived() = %v, %v", v, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := calls.Load(); n != 1 {
+		t.Errorf("compute ran %d times across 100 concurrent calls, want 1", n)
+	}
+}
+
+func TestDerivedInvalidatesOnChange(t *testing.T) {
+	cfg := NewConfig("db", WithValue(5432))
+	calls := 0
+	compute := func(c *Config) (any, error) {
+		calls++
+		return c.Value, nil
+	}
+	cfg.Derived("v", compute)
+	cfg.Derived("v", compute)
+	cfg.Value = int64(5433)
+	v, _ := cfg.Derived("v", compute)
+	if v != int64(5433) || calls != 2 {
+		t.Errorf("after a change Derived() = %v with %d computations, want 5433 with 2", v, calls)
+	}
+	cfg.Clone().Derived("v", compute)
+	if calls != 3 {
+		t.Error("clone reused the source's cache")
+	}
+}
+
+func TestDerivedDoesNotCacheErrors(t *testing.T) {
+	cfg := NewConfig("db")
+	errBoom := errors.New("boom")
+	calls := 0
+	compute := func(*Config) (any, error) {
+		calls++
+		return nil, errBoom
+	}
+	for range 2 {
+		if _, err := cfg.Derived("v", compute); !errors.Is(err, errBoom) {
+			t.Errorf("Derived() error = %v, want %v", err, errBoom)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("compute ran %d times, want 2 since errors are not cached", calls)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+// FieldChange describes one field that differs between two configs. Old
+// is nil for an added config and New is nil for a removed one.
+type FieldChange struct {
+	Field string
+	Old   any
+	New   any
+}
+
+// configField is a named data field of a Config.
+type configField struct {
+	name  string
+	value any
+}
+
+// fields returns the data fields of c in declaration order.
+func (c *Config) fields() []configField {
+	return []configField{
+		{"Name", c.Name},
+		{"Value", c.Value},
+		{"Description", c.Description},
+	}
+}
+
+// Diff lists the fields that differ from a to b, in declaration order.
+//
+// A nil a reports every field of b as added and a nil b reports every field
+// of a as removed; two nil configs have no changes.
+func Diff(a, b *Config) []FieldChange {
+	var changes []FieldChange
+	switch {
+	case a == nil && b == nil:
+	case a == nil:
+		for _, f := range b.fields() {
+			changes = append(changes, FieldChange{Field: f.name, New: f.value})
+		}
+	case b == nil:
+		for _, f := range a.fields() {
+			changes = append(changes, FieldChange{Field: f.name, Old: f.value})
+		}
+	default:
+		newFields := b.fields()
+		for i, f := range a.fields() {
+			if !valuesEqual(f.value, newFields[i].value) {
+				changes = append(changes, FieldChange{Field: f.name, Old: f.value, New: newFields[i].value})
+			}
+		}
+	}
+	return changes
+}
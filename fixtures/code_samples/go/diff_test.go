@@ -0,0 +1,45 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffSingleField(t *testing.T) {
+	a := NewConfig("db", WithValue(5432))
+	b := NewConfig("db", WithValue(5433))
+	want := []FieldChange{{Field: "Value", Old: int64(5432), New: int64(5433)}}
+	if got := Diff(a, b); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff = %v, want %v", got, want)
+	}
+}
+
+func TestDiffNoChange(t *testing.T) {
+	a := NewConfig("db", WithValue(5432), WithDescription("primary"))
+	if got := Diff(a, a.Clone()); len(got) != 0 {
+		t.Errorf("Diff of identical configs = %v, want none", got)
+	}
+	if got := Diff(nil, nil); len(got) != 0 {
+		t.Errorf("Diff(nil, nil) = %v, want none", got)
+	}
+}
+
+func TestDiffNil(t *testing.T) {
+	cfg := NewConfig("db", WithValue(5432))
+	added := []FieldChange{
+		{Field: "Name", New: "db"},
+		{Field: "Value", New: int64(5432)},
+		{Field: "Description", New: ""},
+	}
+	if got := Diff(nil, cfg); !reflect.DeepEqual(got, added) {
+		t.Errorf("Diff(nil, cfg) = %v, want %v", got, added)
+	}
+	removed := []FieldChange{
+		{Field: "Name", Old: "db"},
+		{Field: "Value", Old: int64(5432)},
+		{Field: "Description", Old: ""},
+	}
+	if got := Diff(cfg, nil); !reflect.DeepEqual(got, removed) {
+		t.Errorf("Diff(cfg, nil) = %v, want %v", got, removed)
+	}
+}
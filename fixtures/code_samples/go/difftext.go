@@ -0,0 +1,82 @@
+package main
+
+import (
+	"slices"
+	"strings"
+)
+
+// DiffText renders the change from a to b as a unified-style diff of their
+// DisplayVerbose lines, for logs and review:
+//
+//	--- a
+//	+++ b
+//	 Name:        db
+//	-Value:       5432
+//	+Value:       5433
+//	+Description: primary
+//
+// Unchanged fields are shown as context, and keys are aligned across both
+// sides so only changed fields differ. A nil config contributes no lines.
+// Identical configs produce the empty string.
+func DiffText(a, b *Config) string {
+	var before, after []configField
+	if a != nil {
+		before = a.verboseFields()
+	}
+	if b != nil {
+		after = b.verboseFields()
+	}
+	width := max(keyWidth(before), keyWidth(after))
+
+	var body strings.Builder
+	changed := false
+	for _, name := range diffFieldNames(before, after) {
+		oldField, inOld := lookupField(before, name)
+		newField, inNew := lookupField(after, name)
+		var oldLine, newLine string
+		if inOld {
+			oldLine = a.verboseLine(oldField, width)
+		}
+		if inNew {
+			newLine = b.verboseLine(newField, width)
+		}
+		switch {
+		case inOld && inNew && valuesEqual(oldField.value, newField.value) && oldLine == newLine:
+			body.WriteString(" " + oldLine + "\n")
+			continue
+		case inOld:
+			body.WriteString("-" + oldLine + "\n")
+		}
+		if inNew {
+			body.WriteString("+" + newLine + "\n")
+		}
+		changed = true
+	}
+	if !changed {
+		return ""
+	}
+	return "--- a\n+++ b\n" + body.String()
+}
+
+// diffFieldNames lists the field names shown on either side, in Config
+// declaration order.
+func diffFieldNames(before, after []configField) []string {
+	var names []string
+	for _, f := range (&Config{}).fields() {
+		_, inOld := lookupField(before, f.name)
+		_, inNew := lookupField(after, f.name)
+		if inOld || inNew {
+			names = append(names, f.name)
+		}
+	}
+	return names
+}
+
+// lookupField finds the field called name.
+func lookupField(fields []configField, name string) (configField, bool) {
+	i := slices.IndexFunc(fields, func(f configField) bool { return f.name == name })
+	if i < 0 {
+		return configField{}, false
+	}
+	return fields[i], true
+}
@@ -0,0 +1,38 @@
+package main
+
+import "testing"
+
+func TestDiffTextGolden(t *testing.T) {
+	a := NewConfig("db", WithValue(5432))
+	b := NewConfig("db", WithValue(5433), WithDescription("primary"))
+	checkGolden(t, "difftext.golden", DiffText(a, b))
+}
+
+func TestDiffTextIdentical(t *testing.T) {
+	cfg := NewConfig("db", WithValue(5432), WithDescription("primary"))
+	if got := DiffText(cfg, cfg.Clone()); got != "" {
+		t.Errorf("DiffText of identical configs = %q, want empty", got)
+	}
+	if got := DiffText(nil, nil); got != "" {
+		t.Errorf("DiffText(nil, nil) = %q, want empty", got)
+	}
+}
+
+func TestDiffTextNil(t *testing.T) {
+	cfg := NewConfig("db", WithValue(5432))
+	if got, want := DiffText(nil, cfg), "--- a\n+++ b\n+Name:  db\n+Value: 5432\n"; got != want {
+		t.Errorf("DiffText(nil, cfg) = %q, want %q", got, want)
+	}
+	if got, want := DiffText(cfg, nil), "--- a\n+++ b\n-Name:  db\n-Value: 5432\n"; got != want {
+		t.Errorf("DiffText(cfg, nil) = %q, want %q", got, want)
+	}
+}
+
+func TestDiffTextRedactedChange(t *testing.T) {
+	a := NewConfig("token", WithValue(1), WithSensitiveFields("Value"))
+	b := NewConfig("token", WithValue(2), WithSensitiveFields("Value"))
+	want := "--- a\n+++ b\n Name:  token\n-Value: ****\n+Value: ****\n"
+	if got := DiffText(a, b); got != want {
+		t.Errorf("DiffText of a changed secret = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+)
+
+// LoadDir loads every *.json file in the directory at path. See LoadFS.
+func LoadDir(path string, opts ...MapOption) ([]*Config, error) {
+	return LoadFS(os.DirFS(path), opts...)
+}
+
+// LoadFS parses every *.json file at the root of fsys, as LoadJSON does
+// with opts, and returns the configs sorted by Name.
+//
+// A file that fails to load does not stop the others: the configs that did
+// load are returned together with a joined error naming each failed file.
+func LoadFS(fsys fs.FS, opts ...MapOption) ([]*Config, error) {
+	o := newMapOptions(opts)
+	paths, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, err
+	}
+	cfgs := make([]*Config, 0, len(paths))
+	var errs []error
+	for _, path := range paths {
+		cfg, err := loadJSONFile(fsys, path, o)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			continue
+		}
+		cfgs = append(cfgs, cfg)
+	}
+	slices.SortStableFunc(cfgs, func(a, b *Config) int { return cmp.Compare(a.Name, b.Name) })
+	return cfgs, errors.Join(errs...)
+}
+
+// loadJSONFile reads and decodes a single JSON config file.
+func loadJSONFile(fsys fs.FS, path string, o mapOptions) (*Config, error) {
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON(data, o)
+}
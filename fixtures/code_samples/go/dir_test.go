@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// mapFile returns an fstest file holding data.
+func mapFile(data string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(data)}
+}
+
+func TestLoadFSSortsByName(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.json":        mapFile(`{"name":"zeta","value":1}`),
+		"b.json":        mapFile(`{"name":"alpha","value":2}`),
+		"c.json":        mapFile(`{"name":"mid","value":3}`),
+		"notes.txt":     mapFile(`not a config`),
+		"nested/d.json": mapFile(`{"name":"nested","value":4}`),
+	}
+	cfgs, err := LoadFS(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ConfigSet(cfgs).Names(); strings.Join(got, ",") != "alpha,mid,zeta" {
+		t.Errorf("LoadFS names = %v, want [alpha mid zeta]", got)
+	}
+}
+
+func TestLoadFSPartialFailure(t *testing.T) {
+	fsys := fstest.MapFS{
+		"good.json":   mapFile(`{"name":"good","value":1}`),
+		"broken.json": mapFile(`{"name":`),
+		"noname.json": mapFile(`{"value":1}`),
+	}
+	cfgs, err := LoadFS(fsys)
+	if err == nil {
+		t.Fatal("LoadFS succeeded despite broken files")
+	}
+	for _, name := range []string{"broken.json", "noname.json"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("error %q does not name %s", err, name)
+		}
+	}
+	if len(cfgs) != 1 || cfgs[0].Name != "good" {
+		t.Errorf("LoadFS loaded %v, want only the good config", cfgs)
+	}
+}
+
+func TestLoadFSEmpty(t *testing.T) {
+	cfgs, err := LoadFS(fstest.MapFS{})
+	if err != nil || cfgs == nil || len(cfgs) != 0 {
+		t.Errorf("LoadFS of an empty directory = %#v, %v; want an empty slice and nil", cfgs, err)
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "db.json"), []byte(`{"name":"db","value":5432}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfgs, err := LoadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfgs) != 1 || !cfgs[0].Equal(NewConfig("db", WithValue(5432))) {
+		t.Errorf("LoadDir = %v", cfgs)
+	}
+}
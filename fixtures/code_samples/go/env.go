@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// LoadFromEnv reads <PREFIX>_NAME, <PREFIX>_VALUE and the optional
+// <PREFIX>_DESCRIPTION into a Config.
+//
+// The prefix is case-insensitive: it is upper-cased before lookup, so
+// "app", "App" and "APP" all read APP_NAME and APP_VALUE. Characters
+// other than ASCII letters and digits become underscores, as in ToEnv.
+// The name is required; a missing value defaults to 0.
+func LoadFromEnv(prefix string) (*Config, error) {
+	return loadEnv(prefix, true)
+}
+
+// loadEnv implements LoadFromEnv; without requireName an unset name
+// variable leaves Name empty.
+func loadEnv(prefix string, requireName bool) (*Config, error) {
+	prefix = envPrefix(prefix)
+	nameKey, valueKey := prefix+"_NAME", prefix+"_VALUE"
+
+	name, ok := os.LookupEnv(nameKey)
+	if !ok && requireName {
+		return nil, fmt.Errorf("%w: %s is not set", ErrMissingName, nameKey)
+	}
+	cfg := &Config{Name: name, Value: int64(0)}
+	if ok {
+		cfg.markPresent("Name")
+	}
+	if raw, ok := os.LookupEnv(valueKey); ok {
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("config: %s: %w", valueKey, &ParseError{Field: "Value", Raw: raw, Err: err})
+		}
+		cfg.Value = v
+		cfg.markPresent("Value")
+	}
+	if description, ok := os.LookupEnv(prefix + "_DESCRIPTION"); ok {
+		cfg.Description = description
+		cfg.markPresent("Description")
+	}
+	return cfg, nil
+}
+
+// ToEnv returns the config as KEY=value entries for exec.Cmd.Env, using
+// the variable names LoadFromEnv reads: <PREFIX>_NAME, <PREFIX>_VALUE and,
+// if set, <PREFIX>_DESCRIPTION. The prefix is upper-cased and every
+// character other than an ASCII letter or digit becomes an underscore, so
+// "my-app" yields MY_APP_NAME. LoadFromEnv reads only int64 values, so
+// any other Value is an error wrapping ErrInvalidValue.
+func (c *Config) ToEnv(prefix string) ([]string, error) {
+	value, err := c.int64Value()
+	if err != nil {
+		return nil, err
+	}
+	prefix = envPrefix(prefix)
+	env := []string{
+		prefix + "_NAME=" + c.Name,
+		prefix + "_VALUE=" + strconv.FormatInt(value, 10),
+	}
+	if c.Description != "" {
+		env = append(env, prefix+"_DESCRIPTION="+c.Description)
+	}
+	return env, nil
+}
+
+// SetEnv sets the variables listed by ToEnv in the current process.
+func (c *Config) SetEnv(prefix string) error {
+	env, err := c.ToEnv(prefix)
+	if err != nil {
+		return err
+	}
+	for _, entry := range env {
+		key, value, _ := strings.Cut(entry, "=")
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("config: setting %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// envPrefix normalises a prefix into an environment variable name stem.
+func envPrefix(prefix string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, prefix)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLoadFromEnvPrefixCase(t *testing.T) {
+	t.Setenv("APP_NAME", "db")
+	t.Setenv("APP_VALUE", "5432")
+	want := NewConfig("db", WithValue(5432))
+	for _, prefix := range []string{"APP", "app", "App", "aPp"} {
+		cfg, err := LoadFromEnv(prefix)
+		if err != nil {
+			t.Errorf("LoadFromEnv(%q): %v", prefix, err)
+			continue
+		}
+		if !cfg.Equal(want) {
+			t.Errorf("LoadFromEnv(%q) = %v, want %v", prefix, cfg, want)
+		}
+	}
+}
+
+func TestLoadFromEnvMissingValue(t *testing.T) {
+	t.Setenv("APP_NAME", "db")
+	cfg, err := LoadFromEnv("app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Value != int64(0) {
+		t.Errorf("Value = %v, want 0 when APP_VALUE is unset", cfg.Value)
+	}
+}
+
+func TestLoadFromEnvMissingName(t *testing.T) {
+	t.Setenv("APP_VALUE", "1")
+	if _, err := LoadFromEnv("app"); !errors.Is(err, ErrMissingName) {
+		t.Errorf("LoadFromEnv without APP_NAME = %v, want ErrMissingName", err)
+	}
+}
+
+func TestLoadFromEnvInvalidValue(t *testing.T) {
+	t.Setenv("APP_NAME", "db")
+	t.Setenv("APP_VALUE", "54x32")
+	_, err := LoadFromEnv("App")
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("LoadFromEnv = %v, want a *ParseError", err)
+	}
+	if perr.Field != "Value" || perr.Raw != "54x32" {
+		t.Errorf("ParseError = %+v, want Field Value and Raw 54x32", perr)
+	}
+	if !strings.Contains(err.Error(), "APP_VALUE") {
+		t.Errorf("error %q does not name the variable", err)
+	}
+}
+
+func TestToEnvPrefix(t *testing.T) {
+	got, err := NewConfig("db", WithValue(5432), WithDescription("primary")).ToEnv("my-app.v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"MY_APP_V2_NAME=db", "MY_APP_V2_VALUE=5432", "MY_APP_V2_DESCRIPTION=primary"}
+	if !slices.Equal(got, want) {
+		t.Errorf("ToEnv = %q, want %q", got, want)
+	}
+}
+
+func TestToEnvRoundTrip(t *testing.T) {
+	for _, cfg := range []*Config{
+		NewConfig("db", WithValue(5432), WithDescription("primary")),
+		NewConfig("zero", WithValue(0)),
+		NewConfig("min", WithValue(math.MinInt64)),
+	} {
+		// t.Setenv restores the variables SetEnv leaves behind.
+		for _, key := range []string{"MY_APP_NAME", "MY_APP_VALUE", "MY_APP_DESCRIPTION"} {
+			t.Setenv(key, "")
+			os.Unsetenv(key)
+		}
+		if err := cfg.SetEnv("my-app"); err != nil {
+			t.Fatal(err)
+		}
+		got, err := LoadFromEnv("MY_APP")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(cfg) {
+			t.Errorf("LoadFromEnv after SetEnv = %v, want %v", got, cfg)
+		}
+	}
+}
+
+func TestToEnvNonInt64(t *testing.T) {
+	for _, cfg := range []*Config{
+		NewStringConfig("host", "localhost"),
+		NewBoolConfig("debug", true),
+		NewFloatConfig("ratio", 0.5),
+	} {
+		if _, err := cfg.ToEnv("app"); !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("%v.ToEnv = %v, want ErrInvalidValue", cfg, err)
+		}
+		if err := cfg.SetEnv("app"); !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("%v.SetEnv = %v, want ErrInvalidValue", cfg, err)
+		}
+	}
+}
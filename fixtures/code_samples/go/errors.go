@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors returned, usually wrapped, by validation and loaders.
+// Test for them with errors.Is.
+var (
+	// ErrEmptyName reports a config whose Name is empty.
+	ErrEmptyName = errors.New("config: name must not be empty")
+	// ErrMissingName reports a source that does not provide a name at all.
+	ErrMissingName = errors.New("config: name is missing")
+	// ErrInvalidValue reports a field value of the wrong type or range.
+	ErrInvalidValue = errors.New("config: invalid value")
+	// ErrDuplicateName reports a second config with a name already in use.
+	ErrDuplicateName = errors.New("config: duplicate name")
+	// ErrNotFound reports a Source that has no config to offer.
+	ErrNotFound = errors.New("config: not found")
+	// ErrUnknownSnapshot reports a Rollback to an id that was never taken or
+	// has been evicted.
+	ErrUnknownSnapshot = errors.New("config: unknown or evicted snapshot")
+)
+
+// ParseError reports raw input that could not be parsed into a field.
+// Extract it with errors.As.
+type ParseError struct {
+	Field string // Config field name, such as "Value"
+	Raw   string // the offending input
+	Err   error  // the underlying parse failure
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parsing %s %q: %v", e.Field, e.Raw, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// int64Value returns the Value as an int64, or an ErrInvalidValue error
+// naming the config if it holds another type.
+func (c *Config) int64Value() (int64, error) {
+	v, ok := c.AsInt64()
+	if !ok {
+		return 0, fmt.Errorf("%w for %q: %v is not an int64", ErrInvalidValue, c.Name, c.Value)
+	}
+	return v, nil
+}
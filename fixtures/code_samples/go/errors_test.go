@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseErrorExtraction(t *testing.T) {
+	t.Setenv("BAD_NAME", "db")
+	t.Setenv("BAD_VALUE", "x1")
+	_, envErr := LoadFromEnv("bad")
+	_, mapErr := FromMap(map[string]any{"name": "db", "value": "x2"})
+	jsonErr := json.Unmarshal([]byte(`{"name":"db","value":[3]}`), new(Config))
+	flagErr := new(Config).Set("name=db,value=x4")
+	_, patchErr := NewConfig("db").ApplyMergePatch([]byte(`{"value":{"x":5}}`))
+	_, sizeErr := NewConfigFromSize("db", "6XB")
+	_, csvErr := ReadCSV(strings.NewReader("name,value\ndb,x7\n"))
+
+	tests := []struct {
+		name    string
+		err     error
+		wantRaw string
+	}{
+		{"LoadFromEnv", envErr, "x1"},
+		{"FromMap", mapErr, "x2"},
+		{"UnmarshalJSON", jsonErr, "[3]"},
+		{"Set", flagErr, "x4"},
+		{"ApplyMergePatch", patchErr, `{"x":5}`},
+		{"NewConfigFromSize", sizeErr, "6XB"},
+		{"ReadCSV", csvErr, "x7"},
+	}
+	for _, tt := range tests {
+		var perr *ParseError
+		if !errors.As(tt.err, &perr) {
+			t.Errorf("%s: error %v is not a *ParseError", tt.name, tt.err)
+			continue
+		}
+		if perr.Field != "Value" || perr.Raw != tt.wantRaw || perr.Err == nil {
+			t.Errorf("%s: ParseError = %+v, want Field Value and Raw %q", tt.name, perr, tt.wantRaw)
+		}
+		if !strings.Contains(tt.err.Error(), perr.Error()) {
+			t.Errorf("%s: message %q does not include the parse error", tt.name, tt.err)
+		}
+	}
+}
+
+func TestSentinelErrors(t *testing.T) {
+	var set ConfigSet
+	set.Add(NewConfig("db"))
+	_, tomlErr := LoadTOML(strings.NewReader("name = 1\n"))
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"Validate", NewConfig("").Validate(), ErrEmptyName},
+		{"UnmarshalJSON", json.Unmarshal([]byte(`{}`), new(Config)), ErrMissingName},
+		{"InRange", NewConfig("n", WithValue(5), WithValidators(InRange(0, 1))).Validate(), ErrInvalidValue},
+		{"LoadTOML", tomlErr, ErrInvalidValue},
+		{"ConfigSet.Add", set.Add(NewConfig("db")), ErrDuplicateName},
+		{"Rollback", NewSafeConfig(NewConfig("db")).Rollback(1), ErrUnknownSnapshot},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.want) {
+			t.Errorf("%s: error %v, want %v", tt.name, tt.err, tt.want)
+			continue
+		}
+		if msg := tt.err.Error(); strings.Count(msg, "config:") > 1 {
+			t.Errorf("%s: message %q repeats the config: prefix", tt.name, msg)
+		}
+	}
+}
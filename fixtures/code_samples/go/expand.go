@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// WithEnvExpansion expands ${VAR} and $VAR references in the loaded Name
+// using os.Expand semantics. "$$" yields a literal "$" and undefined
+// variables expand to the empty string.
+func WithEnvExpansion() MapOption {
+	return func(o *mapOptions) { o.expandEnv = true }
+}
+
+// WithStrictExpansion is WithEnvExpansion that fails on undefined
+// variables instead of expanding them to the empty string.
+func WithStrictExpansion() MapOption {
+	return func(o *mapOptions) {
+		o.expandEnv = true
+		o.strictExpansion = true
+	}
+}
+
+// expandEnv expands environment references in s, reporting the first
+// undefined variable when strict is set.
+func expandEnv(s string, strict bool) (string, error) {
+	var undefined string
+	expanded := os.Expand(s, func(name string) string {
+		if name == "$" {
+			return "$"
+		}
+		value, ok := os.LookupEnv(name)
+		if !ok && undefined == "" {
+			undefined = name
+		}
+		return value
+	})
+	if strict && undefined != "" {
+		return "", fmt.Errorf("%w: expanding name %q: $%s is not set", ErrInvalidValue, s, undefined)
+	}
+	return expanded, nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestEnvExpansion(t *testing.T) {
+	t.Setenv("SERVICE", "billing")
+	t.Setenv("RLM_UNDEFINED", "") // restored after the test
+	os.Unsetenv("RLM_UNDEFINED")
+	tests := []struct {
+		name    string
+		opts    []MapOption
+		want    string
+		wantErr bool
+	}{
+		{"${SERVICE}-config", nil, "${SERVICE}-config", false},
+		{"${SERVICE}-config", []MapOption{WithEnvExpansion()}, "billing-config", false},
+		{"$SERVICE", []MapOption{WithEnvExpansion()}, "billing", false},
+		{"x${RLM_UNDEFINED}y", []MapOption{WithEnvExpansion()}, "xy", false},
+		{"x${RLM_UNDEFINED}y", []MapOption{WithStrictExpansion()}, "", true},
+		{"$SERVICE", []MapOption{WithStrictExpansion()}, "billing", false},
+		{"cost: $$5", []MapOption{WithStrictExpansion()}, "cost: $5", false},
+	}
+	for _, tt := range tests {
+		cfg, err := FromMap(map[string]any{"name": tt.name}, tt.opts...)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidValue) || !strings.Contains(err.Error(), "RLM_UNDEFINED") {
+				t.Errorf("FromMap(%q) = %v, %v; want an error naming RLM_UNDEFINED", tt.name, cfg, err)
+			}
+			continue
+		}
+		if err != nil || cfg.Name != tt.want {
+			t.Errorf("FromMap(%q) = %v, %v; want name %q", tt.name, cfg, err, tt.want)
+		}
+	}
+}
+
+func TestEnvExpansionLoaders(t *testing.T) {
+	t.Setenv("SERVICE", "billing")
+	opts := []MapOption{WithEnvExpansion()}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.json")
+	if err := os.WriteFile(path, []byte(`{"name":"${SERVICE}-db"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	load := map[string]func() (*Config, error){
+		"LoadYAML": func() (*Config, error) { return LoadYAML(strings.NewReader("name: ${SERVICE}-db\n"), opts...) },
+		"LoadTOML": func() (*Config, error) { return LoadTOML(strings.NewReader("name = \"${SERVICE}-db\"\n"), opts...) },
+		"LoadJSON": func() (*Config, error) { return LoadJSON(strings.NewReader(`{"name":"${SERVICE}-db"}`), opts...) },
+		"LoadFromReaderContext": func() (*Config, error) {
+			return LoadFromReaderContext(context.Background(), strings.NewReader(`{"name":"${SERVICE}-db"}`), opts...)
+		},
+		"FileSource": FileSource{Path: path, Options: opts}.Load,
+		"LoadFS": func() (*Config, error) {
+			cfgs, err := LoadFS(fstest.MapFS{"a.json": {Data: []byte(`{"name":"${SERVICE}-db"}`)}}, opts...)
+			if err != nil {
+				return nil, err
+			}
+			return cfgs[0], nil
+		},
+		"DecodeStream": func() (*Config, error) {
+			for cfg, err := range DecodeStream(strings.NewReader(`{"name":"${SERVICE}-db"}`), opts...) {
+				return cfg, err
+			}
+			return nil, errors.New("no config")
+		},
+	}
+	for name, fn := range load {
+		cfg, err := fn()
+		if err != nil || cfg.Name != "billing-db" {
+			t.Errorf("%s = %v, %v; want name billing-db", name, cfg, err)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// WithExprValues lets a string Value be an integer arithmetic expression,
+// such as "60 * 60", evaluated with EvalExpr. Without it loaders only
+// accept plain integers.
+func WithExprValues() MapOption {
+	return func(o *mapOptions) { o.exprValues = true }
+}
+
+// LoadWithExpr is LoadYAML with WithExprValues, so "value: 60 * 60" loads
+// as 3600.
+func LoadWithExpr(r io.Reader, opts ...MapOption) (*Config, error) {
+	return LoadYAML(r, append(opts, WithExprValues())...)
+}
+
+// coerceExpr is coerceInt64 that evaluates strings as expressions.
+func coerceExpr(raw any) (int64, error) {
+	if s, ok := raw.(string); ok {
+		return EvalExpr(s)
+	}
+	return coerceInt64(raw)
+}
+
+// EvalExpr evaluates an int64 expression of integer literals, + - * /,
+// unary minus and parentheses, with the usual precedence: "2+3*4" is 14.
+// Division truncates toward zero. Division by zero and any overflow of
+// int64 are errors.
+func EvalExpr(s string) (int64, error) {
+	p := exprParser{src: s}
+	v, err := p.sum()
+	if err != nil {
+		return 0, fmt.Errorf("expression %q: %w", s, err)
+	}
+	if p.skipSpace(); p.pos < len(p.src) {
+		return 0, fmt.Errorf("expression %q: unexpected %q at offset %d", s, p.src[p.pos], p.pos)
+	}
+	return v, nil
+}
+
+var (
+	errDivisionByZero = errors.New("division by zero")
+	errExprOverflow   = errors.New("overflows int64")
+)
+
+// exprParser is a recursive-descent parser that evaluates as it goes.
+type exprParser struct {
+	src string
+	pos int
+}
+
+func (p *exprParser) skipSpace() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// peek returns the next non-space byte, or 0 at the end.
+func (p *exprParser) peek() byte {
+	p.skipSpace()
+	if p.pos < len(p.src) {
+		return p.src[p.pos]
+	}
+	return 0
+}
+
+// sum parses product (('+' | '-') product)*.
+func (p *exprParser) sum() (int64, error) {
+	acc, err := p.product()
+	if err != nil {
+		return 0, err
+	}
+	for op := p.peek(); op == '+' || op == '-'; op = p.peek() {
+		p.pos++
+		rhs, err := p.product()
+		if err != nil {
+			return 0, err
+		}
+		var ok bool
+		if op == '+' {
+			acc, ok = addInt64(acc, rhs)
+		} else {
+			acc, ok = subInt64(acc, rhs)
+		}
+		if !ok {
+			return 0, errExprOverflow
+		}
+	}
+	return acc, nil
+}
+
+// product parses unary (('*' | '/') unary)*.
+func (p *exprParser) product() (int64, error) {
+	acc, err := p.unary()
+	if err != nil {
+		return 0, err
+	}
+	for op := p.peek(); op == '*' || op == '/'; op = p.peek() {
+		p.pos++
+		rhs, err := p.unary()
+		if err != nil {
+			return 0, err
+		}
+		var ok bool
+		if op == '*' {
+			acc, ok = mulInt64(acc, rhs)
+		} else {
+			acc, ok, err = divInt64(acc, rhs)
+			if err != nil {
+				return 0, err
+			}
+		}
+		if !ok {
+			return 0, errExprOverflow
+		}
+	}
+	return acc, nil
+}
+
+// unary parses '-'* primary.
+func (p *exprParser) unary() (int64, error) {
+	if p.peek() != '-' {
+		return p.primary()
+	}
+	p.pos++
+	if p.peek() >= '0' && p.peek() <= '9' {
+		// Negate the literal while parsing it, so math.MinInt64 is
+		// reachable although its magnitude exceeds math.MaxInt64.
+		n, err := p.literal()
+		if err != nil {
+			return 0, err
+		}
+		return -int64(n), nil
+	}
+	v, err := p.unary()
+	if err != nil {
+		return 0, err
+	}
+	if v == math.MinInt64 {
+		return 0, errExprOverflow
+	}
+	return -v, nil
+}
+
+// primary parses a literal or a parenthesised sum.
+func (p *exprParser) primary() (int64, error) {
+	switch c := p.peek(); {
+	case c == '(':
+		p.pos++
+		v, err := p.sum()
+		if err != nil {
+			return 0, err
+		}
+		if p.peek() != ')' {
+			return 0, fmt.Errorf("missing ')' at offset %d", p.pos)
+		}
+		p.pos++
+		return v, nil
+	case c >= '0' && c <= '9':
+		n, err := p.literal()
+		if err != nil {
+			return 0, err
+		}
+		if n > math.MaxInt64 {
+			return 0, errExprOverflow
+		}
+		return int64(n), nil
+	case c == 0:
+		return 0, errors.New("unexpected end of expression")
+	default:
+		return 0, fmt.Errorf("unexpected %q at offset %d", c, p.pos)
+	}
+}
+
+// literal parses a decimal integer of magnitude at most 1<<63.
+func (p *exprParser) literal() (uint64, error) {
+	start := p.pos
+	for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+		p.pos++
+	}
+	n, err := strconv.ParseUint(p.src[start:p.pos], 10, 64)
+	if err != nil || n > 1<<63 {
+		return 0, errExprOverflow
+	}
+	return n, nil
+}
+
+// divInt64 returns a / b and whether the quotient fits in an int64.
+func divInt64(a, b int64) (int64, bool, error) {
+	if b == 0 {
+		return 0, false, errDivisionByZero
+	}
+	if a == math.MinInt64 && b == -1 {
+		return 0, false, nil
+	}
+	return a / b, true, nil
+}
+
+// addInt64 returns a + b and whether the sum fits in an int64.
+func addInt64(a, b int64) (int64, bool) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, false
+	}
+	return a + b, true
+}
+
+// subInt64 returns a - b and whether the difference fits in an int64.
+func subInt64(a, b int64) (int64, bool) {
+	if (b < 0 && a > math.MaxInt64+b) || (b > 0 && a < math.MinInt64+b) {
+		return 0, false
+	}
+	return a - b, true
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestEvalExpr(t *testing.T) {
+	tests := []struct {
+		expr string
+		want int64
+	}{
+		{"42", 42},
+		{"2+3*4", 14},
+		{"(2+3)*4", 20},
+		{" 60 * 60 ", 3600},
+		{"-7/2", -3},
+		{"10-4-3", 3},
+		{"9223372036854775807", math.MaxInt64},
+	}
+	for _, tt := range tests {
+		got, err := EvalExpr(tt.expr)
+		if err != nil {
+			t.Errorf("EvalExpr(%q): %v", tt.expr, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("EvalExpr(%q) = %d, want %d", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestEvalExprErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want error
+	}{
+		{"1/0", errDivisionByZero},
+		{"2/(3-3)", errDivisionByZero},
+		{"9223372036854775807+1", errExprOverflow},
+		{"4611686018427387904*2", errExprOverflow},
+		{"1+", nil},
+		{"(1", nil},
+		{"1 2", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		_, err := EvalExpr(tt.expr)
+		if err == nil {
+			t.Errorf("EvalExpr(%q) succeeded, want an error", tt.expr)
+			continue
+		}
+		if tt.want != nil && !errors.Is(err, tt.want) {
+			t.Errorf("EvalExpr(%q) = %v, want %v", tt.expr, err, tt.want)
+		}
+		if !strings.Contains(err.Error(), tt.expr) {
+			t.Errorf("error %q does not quote the expression", err)
+		}
+	}
+}
+
+func TestLoadWithExpr(t *testing.T) {
+	cfg, err := LoadWithExpr(strings.NewReader("name: timeout\nvalue: \"60 * 60\"\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Value != int64(3600) {
+		t.Errorf("Value = %v, want 3600", cfg.Value)
+	}
+}
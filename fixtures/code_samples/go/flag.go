@@ -0,0 +1,182 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Set parses the "name=test,value=42" form, implementing flag.Value so a
+// config can be bound with flag.Var. Whitespace around keys, values and
+// commas is ignored and values may be double-quoted to hold commas.
+// name is required, value must be an integer and unknown keys are
+// rejected. Attached validators and sensitive fields are kept.
+//
+// String keeps the "name: value" form fmt callers rely on, so with
+// flag.Var(cfg, ...) the default -help prints cannot be passed back to
+// Set. Bind cfg.Flag() instead to show it in the key=value form.
+func (c *Config) Set(s string) error {
+	parsed, err := parseFlagConfig(s)
+	if err != nil {
+		return err
+	}
+	parsed.validators = c.validators
+	parsed.sensitive = c.sensitive
+	*c = *parsed
+	return nil
+}
+
+// Flag returns c as a flag.Value whose String is the key=value form Set
+// accepts, so -help shows a default that can be passed back on the
+// command line. Set updates c.
+func (c *Config) Flag() flag.Value {
+	return configFlag{c}
+}
+
+// configFlag is the flag.Value returned by Config.Flag.
+type configFlag struct {
+	*Config
+}
+
+// String renders the config as MarshalText does, redacting sensitive
+// fields so -help never shows a secret. The flag package calls
+// it on a zero configFlag to detect an unset default, so a nil config is
+// the empty string.
+func (f configFlag) String() string {
+	if f.Config == nil {
+		return ""
+	}
+	text, err := f.MarshalText()
+	if err != nil {
+		return f.Config.String()
+	}
+	return string(text)
+}
+
+// MarshalText encodes the config in the key=value form accepted by Set.
+// Sensitive fields are encoded as "****", since log/slog and other
+// encoders use MarshalText for display; use MarshalTextUnsafe to keep
+// them for a round trip.
+func (c *Config) MarshalText() ([]byte, error) {
+	return c.marshalText(true)
+}
+
+// MarshalTextUnsafe encodes the key=value form without redaction.
+func (c *Config) MarshalTextUnsafe() ([]byte, error) {
+	return c.marshalText(false)
+}
+
+func (c *Config) marshalText(redact bool) ([]byte, error) {
+	name, value, description := quoteFlagValue(c.Name), redactedText, quoteFlagValue(c.Description)
+	if !redact || !c.isSensitive("Value") {
+		v, err := c.int64Value()
+		if err != nil {
+			return nil, err
+		}
+		value = strconv.FormatInt(v, 10)
+	}
+	if redact && c.isSensitive("Name") {
+		name = redactedText
+	}
+	if redact && c.isSensitive("Description") {
+		description = redactedText
+	}
+	pairs := []string{"name=" + name, "value=" + value}
+	if c.Description != "" {
+		pairs = append(pairs, "description="+description)
+	}
+	return []byte(strings.Join(pairs, ",")), nil
+}
+
+// UnmarshalText decodes the key=value form; see Set.
+func (c *Config) UnmarshalText(text []byte) error {
+	return c.Set(string(text))
+}
+
+// parseFlagConfig builds a Config from comma-separated key=value pairs.
+func parseFlagConfig(s string) (*Config, error) {
+	pairs, err := splitFlagPairs(s)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{Value: int64(0)}
+	seen := make(map[string]bool)
+	for _, pair := range pairs {
+		key, raw, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok {
+			return nil, fmt.Errorf("config flag: %q is not key=value", strings.TrimSpace(pair))
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("config flag: duplicate key %q", key)
+		}
+		seen[key] = true
+		value, err := unquoteFlagValue(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("config flag: key %q: %w", key, err)
+		}
+		switch key {
+		case "name":
+			cfg.Name = value
+			cfg.markPresent("Name")
+		case "value":
+			v, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("config flag: %w", &ParseError{Field: "Value", Raw: value, Err: err})
+			}
+			cfg.Value = v
+			cfg.markPresent("Value")
+		case "description":
+			cfg.Description = value
+			cfg.markPresent("Description")
+		default:
+			return nil, fmt.Errorf("config flag: unknown key %q", key)
+		}
+	}
+	if !seen["name"] {
+		return nil, fmt.Errorf("%w: no \"name\" key", ErrMissingName)
+	}
+	return cfg, nil
+}
+
+// splitFlagPairs splits s on commas outside double quotes.
+func splitFlagPairs(s string) ([]string, error) {
+	var pairs []string
+	start, quoted := 0, false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quoted && s[i] == '\\':
+			i++
+		case s[i] == '"':
+			quoted = !quoted
+		case !quoted && s[i] == ',':
+			pairs = append(pairs, s[start:i])
+			start = i + 1
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("config flag: unterminated quote in %q", s)
+	}
+	return append(pairs, s[start:]), nil
+}
+
+// unquoteFlagValue strips the quotes from a double-quoted value.
+func unquoteFlagValue(raw string) (string, error) {
+	if !strings.HasPrefix(raw, `"`) {
+		return raw, nil
+	}
+	s, err := strconv.Unquote(raw)
+	if err != nil {
+		return "", fmt.Errorf("malformed quoted value %s", raw)
+	}
+	return s, nil
+}
+
+// quoteFlagValue quotes s when it would not survive splitFlagPairs as is.
+func quoteFlagValue(s string) string {
+	if s == "" || strings.ContainsAny(s, `,="\`) || strings.TrimSpace(s) != s {
+		return strconv.Quote(s)
+	}
+	return s
+}
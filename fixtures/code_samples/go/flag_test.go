@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSetWhitespace(t *testing.T) {
+	tests := []string{
+		"name=test,value=42",
+		" name = test , value = 42 ",
+		"value=42,\tname=test",
+		`name="test", value="42"`,
+	}
+	want := NewConfig("test", WithValue(42))
+	for _, in := range tests {
+		var cfg Config
+		if err := cfg.Set(in); err != nil {
+			t.Errorf("Set(%q): %v", in, err)
+			continue
+		}
+		if !cfg.Equal(want) {
+			t.Errorf("Set(%q) = %v, want %v", in, &cfg, want)
+		}
+	}
+}
+
+func TestSetQuotedDescription(t *testing.T) {
+	var cfg Config
+	if err := cfg.Set(`name=db,value=1,description="host=a, port=b"`); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Description != "host=a, port=b" {
+		t.Errorf("Description = %q", cfg.Description)
+	}
+}
+
+func TestSetMalformed(t *testing.T) {
+	tests := []struct {
+		in      string
+		wantErr error
+	}{
+		{"", nil},
+		{"value=42", ErrMissingName},
+		{"name=test,value=forty-two", strconv.ErrSyntax},
+		{"name=test,value=1.5", strconv.ErrSyntax},
+		{"name=test,colour=red", nil},
+		{"name=test,name=again", nil},
+		{"name=test,value", nil},
+		{"name=test,,value=1", nil},
+		{`name="test,value=1`, nil},
+		{`name="te"st"`, nil},
+	}
+	for _, tt := range tests {
+		cfg := NewConfig("keep", WithValue(7))
+		err := cfg.Set(tt.in)
+		if err == nil {
+			t.Errorf("Set(%q) succeeded, want error", tt.in)
+			continue
+		}
+		if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+			t.Errorf("Set(%q) = %v, want %v", tt.in, err, tt.wantErr)
+		}
+		if cfg.Name != "keep" || cfg.Value != int64(7) {
+			t.Errorf("failed Set(%q) changed the config to %v", tt.in, cfg)
+		}
+	}
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	cfgs := []*Config{
+		NewConfig("test", WithValue(42)),
+		NewConfig("", WithValue(-1)),
+		NewConfig("a,b", WithValue(0), WithDescription(` "quoted" = yes `)),
+	}
+	for _, want := range cfgs {
+		text, err := want.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got Config
+		if err := got.UnmarshalText(text); err != nil {
+			t.Fatalf("UnmarshalText(%q): %v", text, err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("round trip via %q = %v, want %v", text, &got, want)
+		}
+	}
+}
+
+func TestFlagHelpShowsSettableDefault(t *testing.T) {
+	cfg := NewConfig("test", WithValue(42))
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	fs.Var(cfg.Flag(), "config", "the config")
+	var help strings.Builder
+	fs.SetOutput(&help)
+	fs.PrintDefaults()
+	if !strings.Contains(help.String(), "(default name=test,value=42)") {
+		t.Errorf("-help output %q lacks the key=value default", help.String())
+	}
+
+	fs.SetOutput(io.Discard)
+	if err := fs.Parse([]string{"-config", "name=db, value=5432"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := NewConfig("db", WithValue(5432)); !cfg.Equal(want) {
+		t.Errorf("after -config, cfg = %v, want %v", cfg, want)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MapOption configures FromMap and the loaders that accept it: LoadYAML,
+// LoadTOML, LoadJSON and the JSON loaders built on it.
+type MapOption func(*mapOptions)
+
+type mapOptions struct {
+	strictKeys      bool
+	expandEnv       bool
+	strictExpansion bool
+	exprValues      bool
+}
+
+// WithStrictKeys makes FromMap reject keys that match no Config field.
+func WithStrictKeys() MapOption {
+	return func(o *mapOptions) { o.strictKeys = true }
+}
+
+// FromMap builds a Config from a generic map, matching keys to field names
+// case-insensitively. Unknown keys are ignored unless WithStrictKeys is set.
+//
+// Value is coerced to int64: integral float64s (as produced by
+// encoding/json) and numeric strings are accepted, anything else is an
+// error. A missing or nil value defaults to 0, and a nil description is
+// empty.
+func FromMap(m map[string]any, opts ...MapOption) (*Config, error) {
+	o := newMapOptions(opts)
+
+	m, warnings := canonicalizeKeys(m)
+	cfg := Config{warnings: warnings}
+	var hasName bool
+	var unknown []string
+	for key, raw := range m {
+		switch strings.ToLower(key) {
+		case "name":
+			name, ok := raw.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: key %q: want string, got %T", ErrInvalidValue, key, raw)
+			}
+			cfg.Name, hasName = name, true
+			cfg.markPresent("Name")
+		case "value":
+			coerce := coerceInt64
+			if o.exprValues {
+				coerce = coerceExpr
+			}
+			value, err := coerce(raw)
+			if err != nil {
+				return nil, fmt.Errorf("config: key %q: %w", key, &ParseError{Field: "Value", Raw: fmt.Sprint(raw), Err: err})
+			}
+			cfg.Value = value
+			cfg.markPresent("Value")
+		case "description":
+			description, ok := raw.(string)
+			if !ok && raw != nil {
+				return nil, fmt.Errorf("%w: key %q: want string, got %T", ErrInvalidValue, key, raw)
+			}
+			cfg.Description = description
+			cfg.markPresent("Description")
+		default:
+			unknown = append(unknown, key)
+		}
+	}
+	if !hasName {
+		return nil, fmt.Errorf("%w: no \"name\" key", ErrMissingName)
+	}
+	if err := o.checkKeys(unknown); err != nil {
+		return nil, err
+	}
+	if cfg.Value == nil {
+		cfg.Value = int64(0)
+	}
+	if err := o.expandName(&cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// newMapOptions applies opts in order.
+func newMapOptions(opts []MapOption) mapOptions {
+	var o mapOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// checkKeys rejects the keys that match no field under WithStrictKeys.
+func (o mapOptions) checkKeys(unknown []string) error {
+	if !o.strictKeys || len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return fmt.Errorf("config: unknown keys %q", unknown)
+}
+
+// expandName applies WithEnvExpansion to cfg.Name.
+func (o mapOptions) expandName(cfg *Config) error {
+	if !o.expandEnv {
+		return nil
+	}
+	name, err := expandEnv(cfg.Name, o.strictExpansion)
+	if err != nil {
+		return err
+	}
+	cfg.Name = name
+	return nil
+}
+
+// coerceInt64 converts the loosely typed numbers of generic decoders.
+func coerceInt64(raw any) (int64, error) {
+	switch v := raw.(type) {
+	case nil:
+		return 0, nil
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+			return 0, fmt.Errorf("%v is not an integral int64", v)
+		}
+		return int64(v), nil
+	case string:
+		i, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not an integer: %w", v, err)
+		}
+		return i, nil
+	default:
+		return 0, fmt.Errorf("want a number, got %T", raw)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+)
+
+// Hash returns a stable fingerprint of the config's data fields, as 16 hex
+// digits. Equal configs hash the same in every process, and any field
+// change, including the Value's type, yields a different hash.
+//
+// Each field is written as its name, a type tag and its value bytes, all
+// length-prefixed, into a 64-bit FNV-1a hash. Validators are not hashed.
+func (c *Config) Hash() string {
+	h := fnv.New64a()
+	var buf []byte
+	for _, f := range c.fields() {
+		buf = appendHashBytes(buf[:0], []byte(f.name))
+		buf = appendHashValue(buf, f.value)
+		h.Write(buf)
+	}
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// appendHashValue appends a type tag followed by the value's bytes.
+func appendHashValue(buf []byte, v any) []byte {
+	switch v := v.(type) {
+	case nil:
+		return append(buf, 'n')
+	case int64:
+		return binary.BigEndian.AppendUint64(append(buf, 'i'), uint64(v))
+	case string:
+		return appendHashBytes(append(buf, 's'), []byte(v))
+	case bool:
+		if v {
+			return append(buf, 'b', 1)
+		}
+		return append(buf, 'b', 0)
+	case float64:
+		return binary.BigEndian.AppendUint64(append(buf, 'f'), math.Float64bits(v))
+	default:
+		return appendHashBytes(append(buf, '?'), []byte(fmt.Sprintf("%T:%v", v, v)))
+	}
+}
+
+// appendHashBytes appends b with a length prefix so adjacent fields cannot
+// run into each other.
+func appendHashBytes(buf, b []byte) []byte {
+	buf = binary.BigEndian.AppendUint64(buf, uint64(len(b)))
+	return append(buf, b...)
+}
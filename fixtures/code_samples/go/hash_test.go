@@ -0,0 +1,45 @@
+package main
+
+import "testing"
+
+func TestHashStable(t *testing.T) {
+	// Hard-coded so a change to the encoding, which would invalidate
+	// caches keyed by Hash across processes, fails the test.
+	tests := []struct {
+		cfg  *Config
+		want string
+	}{
+		{NewConfig("db", WithValue(5432)), "9f63f26179a5eb5f"},
+		{NewConfig("db", WithValue(5433)), "60f849129a86f290"},
+		{NewConfig("db", WithValue(5432), WithDescription("primary")), "e5be30ae280dbb98"},
+	}
+	for _, tt := range tests {
+		if got := tt.cfg.Hash(); got != tt.want {
+			t.Errorf("%v.Hash() = %s, want %s", tt.cfg, got, tt.want)
+		}
+	}
+}
+
+func TestHashSensitivity(t *testing.T) {
+	base := NewConfig("db", WithValue(5432))
+	if base.Hash() != base.Clone().Hash() {
+		t.Error("equal configs hash differently")
+	}
+	changed := []*Config{
+		NewConfig("db", WithValue(5433)),
+		NewConfig("db", WithValue(5431)),
+		NewConfig("dc", WithValue(5432)),
+		NewConfig("db", WithValue(5432), WithDescription("x")),
+		NewFloatConfig("db", 5432),
+		NewStringConfig("db", "5432"),
+	}
+	for _, cfg := range changed {
+		if cfg.Hash() == base.Hash() {
+			t.Errorf("%#v hashes the same as %v", cfg, base)
+		}
+	}
+	// Length prefixes keep field boundaries apart.
+	if a, b := (&Config{Name: "ab", Value: int64(0)}).Hash(), (&Config{Name: "a", Value: int64(0), Description: "b"}).Hash(); a == b {
+		t.Error("moving bytes between fields did not change the hash")
+	}
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// configJSON is the wire shape of a Config. GenerateJSONSchema derives the
+// schema from it: keys without omitempty are required, and the schema tag
+// lists JSON types where the Go type does not determine them, including
+// the null that decoding accepts as the zero value.
+type configJSON struct {
+	Name        *string         `json:"name"`
+	Value       json.RawMessage `json:"value,omitempty" schema:"integer,number,string,boolean,null"`
+	Description *string         `json:"description,omitempty" schema:"string,null"`
+}
+
+// MarshalJSON encodes the config as {"name":...,"value":...}. Sensitive
+// fields are encoded as "****"; use MarshalUnsafe to keep them.
+func (c *Config) MarshalJSON() ([]byte, error) {
+	return c.marshalJSON(true)
+}
+
+// MarshalUnsafe encodes the config as JSON without redaction.
+func (c *Config) MarshalUnsafe() ([]byte, error) {
+	return c.marshalJSON(false)
+}
+
+func (c *Config) marshalJSON(redact bool) ([]byte, error) {
+	value, err := encodeJSONValue(c.Value)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %q: %w", c.Name, err)
+	}
+	raw := configJSON{Name: &c.Name, Value: value}
+	if c.Description != "" {
+		raw.Description = &c.Description
+	}
+	if redact {
+		if c.isSensitive("Name") {
+			name := redactedText
+			raw.Name = &name
+		}
+		if c.isSensitive("Value") {
+			raw.Value = json.RawMessage(strconv.Quote(redactedText))
+		}
+		if c.isSensitive("Description") && raw.Description != nil {
+			description := redactedText
+			raw.Description = &description
+		}
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON decodes a config. The name key is required; a missing or
+// null value defaults to int64 zero. Use LoadJSON to apply MapOptions such
+// as WithEnvExpansion.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	data, warnings, err := canonicalizeJSON(data)
+	if err != nil {
+		return err
+	}
+	var raw configJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.Name == nil {
+		return fmt.Errorf("%w: no \"name\" key", ErrMissingName)
+	}
+	value, err := decodeJSONValue(raw.Value)
+	if err != nil {
+		return fmt.Errorf("config %q: %w", *raw.Name, &ParseError{Field: "Value", Raw: string(raw.Value), Err: err})
+	}
+	c.Name = *raw.Name
+	c.Value = value
+	c.Description = ""
+	c.warnings = warnings
+	c.present = []string{"Name"}
+	if raw.Value != nil {
+		c.markPresent("Value")
+	}
+	if raw.Description != nil {
+		c.Description = *raw.Description
+		c.markPresent("Description")
+	}
+	return nil
+}
+
+// LoadJSON reads a JSON config from r as UnmarshalJSON does, then applies
+// opts as FromMap would: WithStrictKeys rejects unknown keys,
+// WithEnvExpansion expands the Name and WithExprValues evaluates a string
+// Value as an expression.
+func LoadJSON(r io.Reader, opts ...MapOption) (*Config, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON(data, newMapOptions(opts))
+}
+
+// decodeJSON implements LoadJSON for data already read.
+func decodeJSON(data []byte, o mapOptions) (*Config, error) {
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	if o.strictKeys {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, err
+		}
+		fields, _ = canonicalizeKeys(fields)
+		var unknown []string
+		for key := range fields {
+			if !slices.ContainsFunc(cfg.fields(), func(f configField) bool { return strings.EqualFold(f.name, key) }) {
+				unknown = append(unknown, key)
+			}
+		}
+		if err := o.checkKeys(unknown); err != nil {
+			return nil, err
+		}
+	}
+	if s, ok := cfg.Value.(string); ok && o.exprValues {
+		v, err := EvalExpr(s)
+		if err != nil {
+			return nil, fmt.Errorf("config %q: %w", cfg.Name, &ParseError{Field: "Value", Raw: s, Err: err})
+		}
+		cfg.Value = v
+	}
+	if err := o.expandName(&cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// encodeJSONValue renders a value so that decodeJSONValue restores the
+// same Go type: whole floats keep a fractional part to stay float64.
+func encodeJSONValue(v any) (json.RawMessage, error) {
+	switch v := v.(type) {
+	case nil:
+		return json.RawMessage("0"), nil
+	case float64:
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return nil, fmt.Errorf("%w: unsupported float %v", ErrInvalidValue, v)
+		}
+		s := strconv.FormatFloat(v, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".eE") {
+			s += ".0"
+		}
+		return json.RawMessage(s), nil
+	case int64, string, bool:
+		return json.Marshal(v)
+	default:
+		return nil, fmt.Errorf("%w: unsupported type %T", ErrInvalidValue, v)
+	}
+}
+
+// decodeJSONValue maps a JSON value onto int64, float64, string or bool.
+func decodeJSONValue(raw json.RawMessage) (any, error) {
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return int64(0), nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s", v)
+		}
+		return f, nil
+	case string, bool:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("unsupported JSON type %T", v)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	tests := []*Config{
+		NewConfig("test", WithValue(42)),
+		NewConfig("", WithValue(7)),
+		NewConfig("neg", WithValue(-1)),
+		NewConfig("max", WithValue(math.MaxInt64)),
+		NewConfig("min", WithValue(math.MinInt64)),
+		NewConfig("described", WithValue(1), WithDescription("a \"quoted\" note")),
+		NewStringConfig("host", "localhost"),
+		NewBoolConfig("debug", true),
+		NewFloatConfig("ratio", 2),
+	}
+	for _, want := range tests {
+		data, err := json.Marshal(want)
+		if err != nil {
+			t.Fatalf("json.Marshal(%v): %v", want, err)
+		}
+		var got Config
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("json.Unmarshal(%s): %v", data, err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("round trip of %v via %s = %v", want, data, &got)
+		}
+	}
+}
+
+func TestMarshalJSONShape(t *testing.T) {
+	data, err := json.Marshal(NewConfig("test", WithValue(42)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"name":"test","value":42}`; string(data) != want {
+		t.Errorf("json.Marshal = %s, want %s", data, want)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    *Config
+		wantErr error
+	}{
+		{in: `{"name":"test","value":42}`, want: NewConfig("test", WithValue(42))},
+		{in: `{"name":"test"}`, want: NewConfig("test", WithValue(0))},
+		{in: `{"name":""}`, want: NewConfig("", WithValue(0))},
+		{in: `{"value":42}`, wantErr: ErrMissingName},
+		{in: `{}`, wantErr: ErrMissingName},
+		{in: `{"name":"test","value":[1]}`},
+		{in: `{"name":"test","value":1e400}`},
+		{in: `[]`},
+	}
+	for _, tt := range tests {
+		var got Config
+		err := json.Unmarshal([]byte(tt.in), &got)
+		switch {
+		case tt.want == nil && err == nil:
+			t.Errorf("json.Unmarshal(%s) = %v, want error", tt.in, &got)
+		case tt.want == nil && tt.wantErr != nil && !errors.Is(err, tt.wantErr):
+			t.Errorf("json.Unmarshal(%s) error = %v, want %v", tt.in, err, tt.wantErr)
+		case tt.want != nil && err != nil:
+			t.Errorf("json.Unmarshal(%s): %v", tt.in, err)
+		case tt.want != nil && !got.Equal(tt.want):
+			t.Errorf("json.Unmarshal(%s) = %v, want %v", tt.in, &got, tt.want)
+		}
+	}
+}
+
+func TestLoadJSONOptions(t *testing.T) {
+	if _, err := LoadJSON(strings.NewReader(`{"name":"x","colour":"red"}`), WithStrictKeys()); err == nil {
+		t.Error("LoadJSON with WithStrictKeys accepted an unknown key")
+	}
+	if _, err := LoadJSON(strings.NewReader(`{"NAME":"x","Value":1}`), WithStrictKeys()); err != nil {
+		t.Errorf("LoadJSON with WithStrictKeys rejected differently cased keys: %v", err)
+	}
+	cfg, err := LoadJSON(strings.NewReader(`{"name":"x","value":"60 * 60"}`), WithExprValues())
+	if err != nil || cfg.Value != int64(3600) {
+		t.Errorf("LoadJSON with WithExprValues = %v, %v; want 3600", cfg, err)
+	}
+	cfg, err = LoadJSON(strings.NewReader(`{"name":"x","value":"60 * 60"}`))
+	if err != nil || cfg.Value != "60 * 60" {
+		t.Errorf("LoadJSON without options = %v, %v; want the string value", cfg, err)
+	}
+}
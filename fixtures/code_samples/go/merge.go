@@ -0,0 +1,89 @@
+package main
+
+import "slices"
+
+// ConfigPatch describes a partial update. Nil fields are absent and leave
+// the target untouched, so an explicit zero can be told apart from unset.
+type ConfigPatch struct {
+	Name *string
+	// Value holds an int64, string, bool or float64; nil means unset.
+	Value       any
+	Description *string
+}
+
+// Merge layers override over base: fields of override that are non-zero
+// or were explicitly set win, other fields fall through to base. A field
+// is explicitly set when an option such as WithValue assigned it or a
+// loader found its key, so WithValue(0) or "value": 0 overrides while an
+// omitted value is inherited. Neither input is modified. A field marked
+// sensitive on either side stays sensitive in the result.
+//
+// Use Apply with a ConfigPatch to override a field of a plain struct
+// literal with its zero value.
+func Merge(base, override *Config) *Config {
+	var patch ConfigPatch
+	if override.Name != "" || override.isPresent("Name") {
+		patch.Name = &override.Name
+	}
+	if !isZeroValue(override.Value) || override.isPresent("Value") {
+		patch.Value = override.Value
+	}
+	if override.Description != "" || override.isPresent("Description") {
+		patch.Description = &override.Description
+	}
+	out := base.Apply(patch)
+	out.markSensitive(override.sensitive...)
+	return out
+}
+
+// Apply returns a new Config with every present field of p set. The
+// result keeps c's sensitive fields, and the fields p sets count as
+// explicitly set.
+func (c *Config) Apply(p ConfigPatch) *Config {
+	out := c.Clone()
+	if p.Name != nil {
+		out.Name = *p.Name
+		out.markPresent("Name")
+	}
+	if p.Value != nil {
+		out.Value = p.Value
+		out.markPresent("Value")
+	}
+	if p.Description != nil {
+		out.Description = *p.Description
+		out.markPresent("Description")
+	}
+	return out
+}
+
+// markPresent records fields, by Config field name, as explicitly set.
+func (c *Config) markPresent(fields ...string) {
+	for _, field := range fields {
+		if !c.isPresent(field) {
+			c.present = append(c.present, field)
+		}
+	}
+}
+
+// isPresent reports whether field was explicitly set; see Merge.
+func (c *Config) isPresent(field string) bool {
+	return slices.Contains(c.present, field)
+}
+
+// isZeroValue reports whether v is nil or the zero value of its type.
+func isZeroValue(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case int64:
+		return v == 0
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMergeEmptyOverrideNameKeepsBase(t *testing.T) {
+	base := NewConfig("db", WithValue(5432), WithDescription("primary"))
+	override := NewConfig("", WithValue(5433))
+	got := Merge(base, override)
+	if want := NewConfig("db", WithValue(5433), WithDescription("primary")); !got.Equal(want) {
+		t.Errorf("Merge = %v, want %v", got, want)
+	}
+	if !base.Equal(NewConfig("db", WithValue(5432), WithDescription("primary"))) || !override.Equal(NewConfig("", WithValue(5433))) {
+		t.Errorf("Merge modified its inputs: base %v, override %v", base, override)
+	}
+}
+
+func TestMergeZeroValueFallsThrough(t *testing.T) {
+	got := Merge(NewConfig("db", WithValue(5432)), &Config{Name: "db", Value: int64(0)})
+	if got.Value != int64(5432) {
+		t.Errorf("Merge with an unset zero override Value = %v, want the base 5432", got.Value)
+	}
+}
+
+func TestMergeExplicitZeroOverrides(t *testing.T) {
+	base := NewConfig("db", WithValue(5432), WithDescription("primary"))
+	var override Config
+	if err := json.Unmarshal([]byte(`{"name":"db","value":0}`), &override); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		name     string
+		override *Config
+	}{
+		{"WithValue", NewConfig("db", WithValue(0))},
+		{"JSON", &override},
+	} {
+		got := Merge(base, tt.override)
+		if want := NewConfig("db", WithValue(0), WithDescription("primary")); !got.Equal(want) {
+			t.Errorf("%s: Merge = %v, want %v", tt.name, got, want)
+		}
+	}
+}
+
+func TestApplyExplicitZeroOverrides(t *testing.T) {
+	base := NewConfig("db", WithValue(5432), WithDescription("primary"))
+	empty := ""
+	got := base.Apply(ConfigPatch{Value: int64(0), Description: &empty})
+	if want := NewConfig("db", WithValue(0)); !got.Equal(want) {
+		t.Errorf("Apply = %v, want %v", got, want)
+	}
+	if base.Value != int64(5432) || base.Description != "primary" {
+		t.Errorf("Apply modified the receiver: %v", base)
+	}
+}
+
+func TestApplyEmptyPatch(t *testing.T) {
+	base := NewConfig("db", WithValue(5432))
+	got := base.Apply(ConfigPatch{})
+	if got == base || !got.Equal(base) {
+		t.Errorf("Apply(ConfigPatch{}) = %p %v, want an equal copy of %p", got, got, base)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// metricName is the Prometheus metric WriteMetrics exports.
+const metricName = "rlm_config_value"
+
+// labelEscaper escapes a Prometheus label value.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// WriteMetrics writes the set in the Prometheus text exposition format,
+// one gauge sample per config sorted by name:
+//
+//	# HELP rlm_config_value Value of each loaded config.
+//	# TYPE rlm_config_value gauge
+//	rlm_config_value{name="db"} 5432
+//
+// Names are escaped as label values, with invalid UTF-8 replaced. Bool
+// values export as 1 or 0; string values have no numeric form and are
+// skipped, as are nil entries.
+func (s ConfigSet) WriteMetrics(w io.Writer) error {
+	sorted := slices.DeleteFunc(slices.Clone(s), func(c *Config) bool { return c == nil })
+	slices.SortStableFunc(sorted, func(a, b *Config) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	bw := bufio.NewWriter(w)
+	bw.WriteString("# HELP " + metricName + " Value of each loaded config.\n")
+	bw.WriteString("# TYPE " + metricName + " gauge\n")
+	for _, c := range sorted {
+		sample, ok := metricValue(c.Value)
+		if !ok {
+			continue
+		}
+		label := labelEscaper.Replace(strings.ToValidUTF8(c.Name, "�"))
+		bw.WriteString(metricName + `{name="` + label + `"} ` + sample + "\n")
+	}
+	return bw.Flush()
+}
+
+// metricValue formats v as a Prometheus sample value.
+func metricValue(v any) (string, bool) {
+	switch v := v.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			return "NaN", true
+		case math.IsInf(v, 1):
+			return "+Inf", true
+		case math.IsInf(v, -1):
+			return "-Inf", true
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64), true
+	case bool:
+		if v {
+			return "1", true
+		}
+		return "0", true
+	default:
+		return "", false
+	}
+}
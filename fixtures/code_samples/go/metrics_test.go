@@ -0,0 +1,29 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	set := ConfigSet{
+		NewConfig("web", WithValue(8080)),
+		nil,
+		NewStringConfig("host", "localhost"),
+		NewConfig("db \"main\"\\\n", WithValue(5432)),
+		NewBoolConfig("debug", true),
+	}
+	var buf bytes.Buffer
+	if err := set.WriteMetrics(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `# HELP rlm_config_value Value of each loaded config.
+# TYPE rlm_config_value gauge
+rlm_config_value{name="db \"main\"\\\n"} 5432
+rlm_config_value{name="debug"} 1
+rlm_config_value{name="web"} 8080
+`
+	if got := buf.String(); got != want {
+		t.Errorf("WriteMetrics =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import "slices"
+
+// Option configures a Config built by NewConfig.
+type Option func(*Config)
+
+// WithValue sets an int64 Value.
+func WithValue(value int64) Option {
+	return func(c *Config) {
+		c.Value = value
+		c.markPresent("Value")
+	}
+}
+
+// WithDescription sets the human-readable Description.
+func WithDescription(description string) Option {
+	return func(c *Config) {
+		c.Description = description
+		c.markPresent("Description")
+	}
+}
+
+// WithDefaults resets Value to the default registered for the config's
+// name and clears Description, discarding whatever earlier options set.
+// Both fields then count as unset for Merge again.
+func WithDefaults() Option {
+	return func(c *Config) {
+		c.Value = defaultValue(c.Name)
+		c.Description = ""
+		c.present = slices.DeleteFunc(c.present, func(f string) bool { return f == "Value" || f == "Description" })
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import "testing"
+
+func TestOptionsApplyInOrder(t *testing.T) {
+	cfg := NewConfig("n", WithValue(5), WithValue(9))
+	if cfg.Value != int64(9) {
+		t.Errorf("NewConfig with WithValue(5), WithValue(9): Value = %v, want 9", cfg.Value)
+	}
+}
+
+func TestNewConfigWithoutOptions(t *testing.T) {
+	cfg := NewConfig("n")
+	if want := (&Config{Name: "n", Value: int64(0)}); !cfg.Equal(want) {
+		t.Errorf("NewConfig(%q) = %v, want %v", "n", cfg, want)
+	}
+	if legacy := NewConfigLegacy("n", 42); !legacy.Equal(NewConfig("n", WithValue(42))) {
+		t.Errorf("NewConfigLegacy = %v", legacy)
+	}
+}
+
+func TestWithDefaultsDiscardsEarlierOptions(t *testing.T) {
+	cfg := NewConfig("n", WithValue(5), WithDescription("set"), WithDefaults(), WithDescription("kept"))
+	if want := NewConfig("n", WithDescription("kept")); !cfg.Equal(want) {
+		t.Errorf("NewConfig = %v, want %v", cfg, want)
+	}
+}
+
+func TestWithDefaultsUsesRegisteredDefault(t *testing.T) {
+	SetDefaults("port", 8080)
+	t.Cleanup(ClearDefaults)
+	if cfg := NewConfig("port"); cfg.Value != int64(8080) {
+		t.Errorf("NewConfig(%q).Value = %v, want the registered 8080", "port", cfg.Value)
+	}
+	if cfg := NewConfig("port", WithValue(0)); cfg.Value != int64(0) {
+		t.Errorf("explicit WithValue(0) lost to the default: %v", cfg.Value)
+	}
+	if cfg := NewConfig("port", WithValue(1), WithDefaults()); cfg.Value != int64(8080) {
+		t.Errorf("WithDefaults().Value = %v, want 8080", cfg.Value)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ApplyMergePatch returns a new Config with an RFC 7386 JSON Merge Patch
+// applied: keys present in patch override the field, keys set to null
+// reset it to its zero value (int64 zero for value), and absent keys leave
+// it untouched. Unknown keys are ignored. The receiver is not modified.
+func (c *Config) ApplyMergePatch(patch []byte) (*Config, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &fields); err != nil {
+		return nil, fmt.Errorf("config: merge patch: %w", err)
+	}
+	if fields == nil {
+		return nil, errors.New("config: merge patch must be a JSON object")
+	}
+	fields, _ = canonicalizeKeys(fields)
+
+	out := c.Clone()
+	for key, raw := range fields {
+		isNull := bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+		switch strings.ToLower(key) {
+		case "name":
+			if err := decodePatchString(raw, isNull, &out.Name); err != nil {
+				return nil, fmt.Errorf("%w: merge patch: key %q: %v", ErrInvalidValue, key, err)
+			}
+			out.markPresent("Name")
+		case "value":
+			value, err := decodeJSONValue(raw)
+			if err != nil {
+				return nil, fmt.Errorf("config: merge patch: %w", &ParseError{Field: "Value", Raw: string(raw), Err: err})
+			}
+			out.Value = value
+			out.markPresent("Value")
+		case "description":
+			if err := decodePatchString(raw, isNull, &out.Description); err != nil {
+				return nil, fmt.Errorf("%w: merge patch: key %q: %v", ErrInvalidValue, key, err)
+			}
+			out.markPresent("Description")
+		}
+	}
+	return out, nil
+}
+
+// decodePatchString sets *dst from a JSON string, or clears it for null.
+func decodePatchString(raw json.RawMessage, isNull bool, dst *string) error {
+	if isNull {
+		*dst = ""
+		return nil
+	}
+	if err := json.Unmarshal(raw, dst); err != nil {
+		return errors.New("want a string")
+	}
+	return nil
+}
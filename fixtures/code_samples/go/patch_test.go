@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestApplyMergePatch(t *testing.T) {
+	base := NewConfig("db", WithValue(5432), WithDescription("primary"))
+	tests := []struct {
+		name  string
+		patch string
+		want  *Config
+	}{
+		{"empty", `{}`, NewConfig("db", WithValue(5432), WithDescription("primary"))},
+		{"value", `{"value":6543}`, NewConfig("db", WithValue(6543), WithDescription("primary"))},
+		{"null value", `{"value":null}`, NewConfig("db", WithValue(0), WithDescription("primary"))},
+		{"null description", `{"description":null}`, NewConfig("db", WithValue(5432))},
+		{"name", `{"Name":"replica","unknown":true}`, NewConfig("replica", WithValue(5432), WithDescription("primary"))},
+	}
+	for _, tt := range tests {
+		got, err := base.ApplyMergePatch([]byte(tt.patch))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: ApplyMergePatch(%s) = %v, want %v", tt.name, tt.patch, got, tt.want)
+		}
+	}
+	if !base.Equal(NewConfig("db", WithValue(5432), WithDescription("primary"))) {
+		t.Errorf("ApplyMergePatch modified the receiver: %v", base)
+	}
+}
+
+func TestApplyMergePatchInvalid(t *testing.T) {
+	base := NewConfig("db")
+	for _, patch := range []string{`null`, `[1]`, `{"name":1}`, `{"value":[1]}`} {
+		if _, err := base.ApplyMergePatch([]byte(patch)); err == nil {
+			t.Errorf("ApplyMergePatch(%s) succeeded, want an error", patch)
+		}
+	}
+	_, err := base.ApplyMergePatch([]byte(`{"value":[1]}`))
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Field != "Value" {
+		t.Errorf("ApplyMergePatch with a bad value = %v, want a ParseError for Value", err)
+	}
+}
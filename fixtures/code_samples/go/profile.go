@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DefaultProfile names the profile every other profile inherits from.
+const DefaultProfile = "default"
+
+// Profiles holds one Config per profile name, such as "dev" or "prod".
+type Profiles map[string]*Config
+
+// Active returns the config for profile layered over the default profile
+// with Merge semantics: fields the profile leaves unset, such as an
+// omitted Value, are inherited from the default, while a field set
+// explicitly, even to zero, wins. The result is always a new Config.
+func (p Profiles) Active(profile string) (*Config, error) {
+	cfg, ok := p[profile]
+	if !ok {
+		return nil, fmt.Errorf("config: unknown profile %q (available: %s)", profile, strings.Join(p.names(), ", "))
+	}
+	base, ok := p[DefaultProfile]
+	if !ok || profile == DefaultProfile {
+		return cfg.Clone(), nil
+	}
+	return Merge(base, cfg), nil
+}
+
+// names returns the profile names in sorted order.
+func (p Profiles) names() []string {
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
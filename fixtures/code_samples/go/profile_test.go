@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProfilesActive(t *testing.T) {
+	profiles := Profiles{
+		DefaultProfile: NewConfig("app", WithValue(8080), WithDescription("defaults")),
+		"dev":          NewConfig("app-dev"),
+		"test":         NewConfig("app-test", WithValue(0)),
+	}
+	for _, tt := range []struct {
+		profile string
+		want    *Config
+	}{
+		{DefaultProfile, NewConfig("app", WithValue(8080), WithDescription("defaults"))},
+		{"dev", NewConfig("app-dev", WithValue(8080), WithDescription("defaults"))},
+		{"test", NewConfig("app-test", WithValue(0), WithDescription("defaults"))},
+	} {
+		got, err := profiles.Active(tt.profile)
+		if err != nil {
+			t.Fatalf("Active(%q): %v", tt.profile, err)
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("Active(%q) = %v, want %v", tt.profile, got, tt.want)
+		}
+		if got == profiles[tt.profile] {
+			t.Errorf("Active(%q) returned the stored config, want a copy", tt.profile)
+		}
+	}
+}
+
+func TestProfilesActiveFromJSON(t *testing.T) {
+	load := func(doc string) *Config {
+		t.Helper()
+		cfg, err := LoadJSON(strings.NewReader(doc))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cfg
+	}
+	profiles := Profiles{
+		DefaultProfile: load(`{"name":"app","value":8080}`),
+		"dev":          load(`{"name":"app"}`),
+		"test":         load(`{"name":"app","value":0}`),
+	}
+	for profile, want := range map[string]int64{"dev": 8080, "test": 0} {
+		got, err := profiles.Active(profile)
+		if err != nil {
+			t.Fatalf("Active(%q): %v", profile, err)
+		}
+		if got.Value != want {
+			t.Errorf("Active(%q).Value = %v, want %d", profile, got.Value, want)
+		}
+	}
+}
+
+func TestProfilesActiveUnknown(t *testing.T) {
+	profiles := Profiles{DefaultProfile: NewConfig("app"), "prod": NewConfig("app")}
+	_, err := profiles.Active("staging")
+	if err == nil {
+		t.Fatal("Active(\"staging\") succeeded, want an error")
+	}
+	if want := `config: unknown profile "staging" (available: default, prod)`; err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestGenerateConfigDeterministic(t *testing.T) {
+	for seed := range int64(50) {
+		a, b := GenerateConfig(seed), GenerateConfig(seed)
+		if !a.Equal(b) {
+			t.Errorf("seed %d: GenerateConfig gave %v then %v", seed, a.DisplayUnsafe(), b.DisplayUnsafe())
+		}
+	}
+	if GenerateConfig(1).Equal(GenerateConfig(2)) {
+		t.Error("seeds 1 and 2 generated equal configs")
+	}
+}
+
+func TestGenerateConfigEdgeCases(t *testing.T) {
+	var emptyName, unicodeName, minValue, maxValue bool
+	for seed := range int64(quickCheckRuns) {
+		cfg := GenerateConfig(seed)
+		v, ok := cfg.AsInt64()
+		if !ok {
+			t.Fatalf("seed %d: Value %T is not an int64", seed, cfg.Value)
+		}
+		emptyName = emptyName || cfg.Name == ""
+		unicodeName = unicodeName || utf8.RuneCountInString(cfg.Name) != len(cfg.Name)
+		minValue = minValue || v == math.MinInt64
+		maxValue = maxValue || v == math.MaxInt64
+	}
+	for edge, seen := range map[string]bool{
+		"empty name":   emptyName,
+		"unicode name": unicodeName,
+		"MinInt64":     minValue,
+		"MaxInt64":     maxValue,
+	} {
+		if !seen {
+			t.Errorf("no %s in %d seeds", edge, quickCheckRuns)
+		}
+	}
+}
+
+func TestQuickCheckRoundTrip(t *testing.T) {
+	codecs := []struct {
+		name      string
+		marshal   func(*Config) ([]byte, error)
+		unmarshal func([]byte) (*Config, error)
+	}{
+		{"JSON", (*Config).MarshalUnsafe, func(data []byte) (*Config, error) {
+			var cfg Config
+			return &cfg, json.Unmarshal(data, &cfg)
+		}},
+		{"binary", (*Config).MarshalBinary, func(data []byte) (*Config, error) {
+			var cfg Config
+			return &cfg, cfg.UnmarshalBinary(data)
+		}},
+		{"text", (*Config).MarshalTextUnsafe, func(data []byte) (*Config, error) {
+			var cfg Config
+			return &cfg, cfg.UnmarshalText(data)
+		}},
+		{"TOML", func(cfg *Config) ([]byte, error) {
+			var buf bytes.Buffer
+			err := SaveTOML(&buf, cfg)
+			return buf.Bytes(), err
+		}, func(data []byte) (*Config, error) {
+			return LoadTOML(bytes.NewReader(data))
+		}},
+	}
+	for _, c := range codecs {
+		t.Run(c.name, func(t *testing.T) {
+			QuickCheckRoundTrip(t, c.marshal, c.unmarshal)
+		})
+	}
+}
+
+// fatalTB records the first Fatalf and stops the caller as testing.T does.
+type fatalTB struct{ msg string }
+
+func (*fatalTB) Helper() {}
+
+func (f *fatalTB) Fatalf(format string, args ...any) {
+	f.msg = fmt.Sprintf(format, args...)
+	panic(f)
+}
+
+func TestQuickCheckRoundTripLossy(t *testing.T) {
+	// Dropping the description loses data for every seed that has one.
+	lossy := func(cfg *Config) ([]byte, error) {
+		return NewConfig(cfg.Name, WithValue(cfg.Value.(int64))).MarshalUnsafe()
+	}
+	unmarshal := func(data []byte) (*Config, error) {
+		var cfg Config
+		return &cfg, json.Unmarshal(data, &cfg)
+	}
+	tb := &fatalTB{}
+	func() {
+		defer func() {
+			if r := recover(); r != nil && r != tb {
+				panic(r)
+			}
+		}()
+		QuickCheckRoundTrip(tb, lossy, unmarshal)
+	}()
+	if tb.msg == "" {
+		t.Fatal("QuickCheckRoundTrip passed a lossy codec")
+	}
+	if !strings.HasPrefix(tb.msg, "seed ") {
+		t.Errorf("failure %q does not report the seed", tb.msg)
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import "slices"
+
+// redactedText replaces sensitive field values in displayed output.
+const redactedText = "****"
+
+// WithSensitiveFields marks fields, by Config field name such as "Value",
+// as sensitive. String, Display, DisplayVerbose, MarshalJSON, MarshalText
+// and Flag().String() then show "****" in their place. Names that match
+// no field have no effect.
+func WithSensitiveFields(fields ...string) Option {
+	return func(c *Config) { c.markSensitive(fields...) }
+}
+
+// markSensitive adds the fields not already marked sensitive.
+func (c *Config) markSensitive(fields ...string) {
+	for _, field := range fields {
+		if !c.isSensitive(field) {
+			c.sensitive = append(c.sensitive, field)
+		}
+	}
+}
+
+// isSensitive reports whether field was marked with WithSensitiveFields.
+func (c *Config) isSensitive(field string) bool {
+	return slices.Contains(c.sensitive, field)
+}
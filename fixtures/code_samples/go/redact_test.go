@@ -0,0 +1,118 @@
+package main
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+const secret = "1234"
+
+// leaks reports where, if anywhere, the secret value shows up in the
+// redacted output of cfg.
+func leaks(cfg *Config) string {
+	jsonText, _ := cfg.MarshalJSON()
+	text, _ := cfg.MarshalText()
+	var textLog, jsonLog strings.Builder
+	slog.New(slog.NewTextHandler(&textLog, nil)).Info("loaded", "cfg", cfg)
+	slog.New(slog.NewJSONHandler(&jsonLog, nil)).Info("loaded", "cfg", cfg)
+	for name, out := range map[string]string{
+		"String":          cfg.String(),
+		"Display":         cfg.Display(),
+		"DisplayVerbose":  cfg.DisplayVerbose(),
+		"MarshalJSON":     string(jsonText),
+		"MarshalText":     string(text),
+		"Flag().String()": cfg.Flag().String(),
+		"slog text":       textLog.String(),
+		"slog JSON":       jsonLog.String(),
+	} {
+		if strings.Contains(out, secret) {
+			return name + " = " + out
+		}
+	}
+	return ""
+}
+
+func TestSecretNeverDisplayed(t *testing.T) {
+	sensitive := func() *Config {
+		return NewConfig("db", WithValue(1234), WithSensitiveFields("Value"))
+	}
+	set := sensitive()
+	if err := set.Set("name=db,value=1234"); err != nil {
+		t.Fatal(err)
+	}
+	unmarshaled := sensitive()
+	if err := unmarshaled.UnmarshalText([]byte("name=db,value=1234")); err != nil {
+		t.Fatal(err)
+	}
+	patched, err := sensitive().ApplyMergePatch([]byte(`{"value": 1234}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	active, err := Profiles{
+		DefaultProfile: NewConfig("db", WithValue(1)),
+		"prod":         sensitive(),
+	}.Active("prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolved, err := NewResolver(
+		DefaultSource{Config: NewConfig("db", WithValue(1))},
+		DefaultSource{Config: NewConfig("", WithValue(1234), WithSensitiveFields("Value"))},
+	).Resolve()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]*Config{
+		"option":             sensitive(),
+		"clone":              sensitive().Clone(),
+		"Set":                set,
+		"UnmarshalText":      unmarshaled,
+		"ApplyMergePatch":    patched,
+		"Apply":              sensitive().Apply(ConfigPatch{Description: new(string)}),
+		"Merge base":         Merge(sensitive(), NewConfig("", WithDescription("primary"))),
+		"Merge override":     Merge(NewConfig("db", WithValue(1)), NewConfig("", WithValue(1234), WithSensitiveFields("Value"))),
+		"Profiles.Active":    active,
+		"Resolver.Resolve":   resolved,
+		"SafeConfig.Get":     func() *Config { c := NewSafeConfig(sensitive()).Get(); return &c }(),
+		"Set then Set again": func() *Config { c := sensitive(); c.Set("name=db,value=99"); c.Set("name=db,value=1234"); return c }(),
+	}
+	for name, cfg := range tests {
+		if where := leaks(cfg); where != "" {
+			t.Errorf("%s: secret leaked in %s", name, where)
+		}
+	}
+}
+
+func TestDisplayUnsafeShowsSecret(t *testing.T) {
+	cfg := NewConfig("db", WithValue(1234), WithSensitiveFields("Value"))
+	if got := cfg.DisplayUnsafe(); got != "db: 1234" {
+		t.Errorf("DisplayUnsafe() = %q, want %q", got, "db: 1234")
+	}
+	text, err := cfg.MarshalUnsafe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(text), secret) {
+		t.Errorf("MarshalUnsafe() = %s, want the real value", text)
+	}
+}
+
+func TestMarshalTextUnsafeRoundTrip(t *testing.T) {
+	want := NewConfig("db", WithValue(1234), WithDescription("x"), WithSensitiveFields("Name", "Value", "Description"))
+	if text, _ := want.MarshalText(); string(text) != "name=****,value=****,description=****" {
+		t.Errorf("MarshalText() = %s", text)
+	}
+	text, err := want.MarshalTextUnsafe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := NewConfig("", WithSensitiveFields("Value"))
+	if err := got.UnmarshalText(text); err != nil {
+		t.Fatalf("UnmarshalText(%s): %v", text, err)
+	}
+	if !got.Equal(want) {
+		t.Errorf("round trip via %s = %#v", text, got)
+	}
+}
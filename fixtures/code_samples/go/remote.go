@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// HTTPOption configures LoadFromURL.
+type HTTPOption func(*httpOptions)
+
+type httpOptions struct {
+	client  *http.Client
+	mapOpts []MapOption
+}
+
+// WithHTTPClient replaces http.DefaultClient for LoadFromURL.
+func WithHTTPClient(client *http.Client) HTTPOption {
+	return func(o *httpOptions) { o.client = client }
+}
+
+// WithHTTPLoadOptions passes opts to the JSON decoding of LoadFromURL, as
+// for LoadJSON.
+func WithHTTPLoadOptions(opts ...MapOption) HTTPOption {
+	return func(o *httpOptions) { o.mapOpts = append(o.mapOpts, opts...) }
+}
+
+// LoadFromReaderContext decodes a JSON config from r as LoadJSON does,
+// returning ctx.Err() as soon as ctx is done.
+//
+// A read blocked inside r cannot be interrupted; on cancellation it is
+// abandoned and finishes in the background, so close r to release it.
+func LoadFromReaderContext(ctx context.Context, r io.Reader, opts ...MapOption) (*Config, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	type result struct {
+		cfg *Config
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		cfg, err := LoadJSON(r, opts...)
+		done <- result{cfg: cfg, err: err}
+	}()
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-done:
+		return res.cfg, res.err
+	}
+}
+
+// LoadFromURL fetches and decodes a JSON config over HTTP, honouring ctx
+// deadlines for both the request and the body read.
+func LoadFromURL(ctx context.Context, url string, opts ...HTTPOption) (*Config, error) {
+	o := httpOptions{client: http.DefaultClient}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("config: GET %s: %s", url, resp.Status)
+	}
+	return LoadFromReaderContext(ctx, resp.Body, o.mapOpts...)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadFromURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"name":"db","value":5432}`)
+	}))
+	defer srv.Close()
+	cfg, err := LoadFromURL(context.Background(), srv.URL, WithHTTPClient(srv.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Equal(NewConfig("db", WithValue(5432))) {
+		t.Errorf("LoadFromURL = %v", cfg)
+	}
+}
+
+func TestLoadFromURLStatus(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	if _, err := LoadFromURL(context.Background(), srv.URL); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("LoadFromURL of a 404 = %v, want an error naming the status", err)
+	}
+}
+
+func TestLoadFromURLCancelledMidRead(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"name":`)
+		w.(http.Flusher).Flush()
+		select {
+		case <-release:
+		case <-time.After(10 * time.Second):
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := LoadFromURL(ctx, srv.URL)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("LoadFromURL past its deadline = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("LoadFromURL took %v to notice the deadline", elapsed)
+	}
+}
+
+func TestLoadFromReaderContextCancelled(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		io.WriteString(w, `{"name":`)
+		cancel()
+	}()
+	if _, err := LoadFromReaderContext(ctx, r); !errors.Is(err, context.Canceled) {
+		t.Errorf("LoadFromReaderContext = %v, want %v", err, context.Canceled)
+	}
+	if _, err := LoadFromReaderContext(ctx, strings.NewReader(`{"name":"db"}`)); !errors.Is(err, context.Canceled) {
+		t.Errorf("LoadFromReaderContext with a done context = %v, want %v", err, context.Canceled)
+	}
+}
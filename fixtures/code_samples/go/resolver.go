@@ -0,0 +1,127 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Source supplies one layer of configuration. Load returns an error
+// wrapping ErrNotFound when the source has nothing to offer.
+type Source interface {
+	Load() (*Config, error)
+}
+
+// Resolver merges the configs of its sources, ordered from lowest to
+// highest precedence.
+type Resolver struct {
+	Sources []Source
+}
+
+// NewResolver returns a Resolver over sources, lowest precedence first,
+// e.g. NewResolver(DefaultSource{...}, FileSource{...}, EnvSource{...}).
+func NewResolver(sources ...Source) *Resolver {
+	return &Resolver{Sources: sources}
+}
+
+// Resolve loads every source and layers each over the previous ones with
+// Merge, so the fields a later source sets win, even when zero, and the
+// fields it leaves unset fall through to earlier ones. Sources reporting
+// ErrNotFound are skipped; any other failure aborts resolution with an
+// error naming the source. If no source provides a config, the error
+// wraps ErrNotFound.
+func (r *Resolver) Resolve() (*Config, error) {
+	var merged *Config
+	for _, src := range r.Sources {
+		cfg, err := src.Load()
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("config: source %v: %w", src, err)
+		}
+		if merged == nil {
+			merged = cfg.Clone()
+		} else {
+			merged = Merge(merged, cfg)
+		}
+	}
+	if merged == nil {
+		return nil, fmt.Errorf("%w: no source provided a config", ErrNotFound)
+	}
+	return merged, nil
+}
+
+// DefaultSource supplies a fixed config, typically the built-in defaults.
+type DefaultSource struct {
+	Config *Config
+}
+
+// Load returns a copy of the config, or ErrNotFound if it is nil.
+func (s DefaultSource) Load() (*Config, error) {
+	if s.Config == nil {
+		return nil, ErrNotFound
+	}
+	return s.Config.Clone(), nil
+}
+
+func (s DefaultSource) String() string { return "defaults" }
+
+// FileSource loads a config file, choosing the format by extension:
+// .json, .yaml or .yml, or .toml. Options are passed to the loader.
+type FileSource struct {
+	Path    string
+	Options []MapOption
+}
+
+// Load reads the file, reporting ErrNotFound if it does not exist.
+func (s FileSource) Load() (*Config, error) {
+	f, err := os.Open(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	switch ext := strings.ToLower(filepath.Ext(s.Path)); ext {
+	case ".json":
+		return LoadJSON(f, s.Options...)
+	case ".yaml", ".yml":
+		return LoadYAML(f, s.Options...)
+	case ".toml":
+		return LoadTOML(f, s.Options...)
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q", ext)
+	}
+}
+
+func (s FileSource) String() string { return "file " + s.Path }
+
+// EnvSource reads <PREFIX>_NAME, <PREFIX>_VALUE and <PREFIX>_DESCRIPTION
+// as LoadFromEnv does, except that the name is optional so the
+// environment can override just the value. Only the variables that are
+// set override earlier sources. It reports ErrNotFound when none of the
+// variables is set.
+type EnvSource struct {
+	Prefix string
+}
+
+// Load reads the environment.
+func (s EnvSource) Load() (*Config, error) {
+	prefix := envPrefix(s.Prefix)
+	found := false
+	for _, suffix := range []string{"_NAME", "_VALUE", "_DESCRIPTION"} {
+		if _, ok := os.LookupEnv(prefix + suffix); ok {
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: no %s_* variables set", ErrNotFound, prefix)
+	}
+	return loadEnv(s.Prefix, false)
+}
+
+func (s EnvSource) String() string { return "env " + envPrefix(s.Prefix) + "_*" }
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// tempFile writes data to name in a fresh temporary directory.
+func tempFile(t *testing.T, name, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	writeFile(t, path, data)
+	return path
+}
+
+func TestResolvePrecedence(t *testing.T) {
+	defaults := DefaultSource{NewConfig("app", WithValue(8080), WithDescription("built-in"))}
+	file := FileSource{Path: tempFile(t, "app.yaml", "name: app-file\nvalue: 9090\n")}
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		want *Config
+	}{
+		{"file over defaults", nil, NewConfig("app-file", WithValue(9090), WithDescription("built-in"))},
+		{"env over file", map[string]string{"APP_VALUE": "7070"}, NewConfig("app-file", WithValue(7070), WithDescription("built-in"))},
+		{"env zero over file", map[string]string{"APP_VALUE": "0"}, NewConfig("app-file", WithValue(0), WithDescription("built-in"))},
+		{"env empty description", map[string]string{"APP_DESCRIPTION": ""}, NewConfig("app-file", WithValue(9090))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			got, err := NewResolver(defaults, file, EnvSource{Prefix: "app"}).Resolve()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Resolve = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveFileZeroOverDefaults(t *testing.T) {
+	defaults := DefaultSource{NewConfig("app", WithValue(8080))}
+	file := FileSource{Path: tempFile(t, "app.json", `{"name":"app","value":0}`)}
+	got, err := NewResolver(defaults, file).Resolve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Value != int64(0) {
+		t.Errorf("Value = %v, want the file's explicit 0", got.Value)
+	}
+}
+
+func TestResolveSkipsMissingSources(t *testing.T) {
+	missing := FileSource{Path: filepath.Join(t.TempDir(), "absent.toml")}
+	_, err := NewResolver(missing, DefaultSource{}).Resolve()
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Resolve with no configs = %v, want ErrNotFound", err)
+	}
+
+	got, err := NewResolver(DefaultSource{NewConfig("app", WithValue(1))}, missing).Resolve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(NewConfig("app", WithValue(1))) {
+		t.Errorf("Resolve = %v, want the defaults", got)
+	}
+}
+
+func TestResolveNamesFailingSource(t *testing.T) {
+	file := FileSource{Path: tempFile(t, "app.json", `{"value":1}`)}
+	_, err := NewResolver(DefaultSource{NewConfig("app")}, file).Resolve()
+	if !errors.Is(err, ErrMissingName) || !strings.Contains(err.Error(), file.Path) {
+		t.Errorf("Resolve = %v, want ErrMissingName naming %s", err, file.Path)
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// SafeConfig guards a Config for concurrent readers and writers.
+type SafeConfig struct {
+	mu  sync.RWMutex
+	cfg Config
+
+	watchers    map[int]chan Config
+	nextWatcher int
+
+	history      []snapshot
+	historyDepth int
+	nextSnapshot int
+}
+
+// SafeOption configures a SafeConfig.
+type SafeOption func(*SafeConfig)
+
+// defaultHistoryDepth is how many snapshots a SafeConfig keeps by default.
+const defaultHistoryDepth = 10
+
+// WithHistoryDepth sets how many snapshots are kept for Rollback; the
+// oldest is evicted once the limit is reached. Values below 1 mean 1.
+func WithHistoryDepth(depth int) SafeOption {
+	return func(s *SafeConfig) { s.historyDepth = max(depth, 1) }
+}
+
+// NewSafeConfig wraps a copy of cfg.
+func NewSafeConfig(cfg *Config, opts ...SafeOption) *SafeConfig {
+	s := &SafeConfig{historyDepth: defaultHistoryDepth}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.Set(cfg)
+	return s
+}
+
+// Get returns a copy of the current config; mutating it does not affect
+// the shared state.
+func (s *SafeConfig) Get() Config {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return *s.cfg.Clone()
+}
+
+// Set replaces the current config with a copy of cfg.
+func (s *SafeConfig) Set(cfg *Config) {
+	next := cfg.Clone()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cfg = *next
+	s.notify()
+}
+
+// Update runs fn on the current config under the write lock, for
+// read-modify-write changes.
+func (s *SafeConfig) Update(fn func(*Config)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(&s.cfg)
+	s.notify()
+}
+
+// Watch subscribes to changes: the returned channel receives a copy of the
+// config after every Set or Update. Each subscriber has its own channel
+// holding at most one pending value; a subscriber that falls behind skips
+// to the latest config instead of blocking writers or other subscribers.
+//
+// Call the returned func to unsubscribe. It closes the channel, is safe to
+// call more than once, and must be called to release the subscription.
+func (s *SafeConfig) Watch() (<-chan Config, func()) {
+	ch := make(chan Config, 1)
+	s.mu.Lock()
+	if s.watchers == nil {
+		s.watchers = make(map[int]chan Config)
+	}
+	id := s.nextWatcher
+	s.nextWatcher++
+	s.watchers[id] = ch
+	s.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			delete(s.watchers, id)
+			close(ch)
+		})
+	}
+}
+
+// notify sends the current config to every watcher, replacing any value
+// still pending. Callers hold the write lock.
+func (s *SafeConfig) notify() {
+	for _, ch := range s.watchers {
+		next := *s.cfg.Clone()
+		for sent := false; !sent; {
+			select {
+			case ch <- next:
+				sent = true
+			default:
+				select {
+				case <-ch:
+				default:
+				}
+			}
+		}
+	}
+}
+
+// snapshot is a saved config and its version id.
+type snapshot struct {
+	id  int
+	cfg *Config
+}
+
+// Snapshot saves a copy of the current config and returns its version id
+// for Rollback. Only the most recent snapshots are kept; see
+// WithHistoryDepth.
+func (s *SafeConfig) Snapshot() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextSnapshot++
+	if len(s.history) >= s.historyDepth {
+		s.history = slices.Delete(s.history, 0, len(s.history)-s.historyDepth+1)
+	}
+	s.history = append(s.history, snapshot{id: s.nextSnapshot, cfg: s.cfg.Clone()})
+	return s.nextSnapshot
+}
+
+// Rollback restores the config saved by Snapshot under id and notifies
+// watchers. The restored config is a copy, so later changes leave the
+// snapshot intact and it can be rolled back to again. Unknown and evicted
+// ids are errors wrapping ErrUnknownSnapshot.
+func (s *SafeConfig) Rollback(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.IndexFunc(s.history, func(snap snapshot) bool { return snap.id == id })
+	if i < 0 {
+		return fmt.Errorf("%w: %d", ErrUnknownSnapshot, id)
+	}
+	s.cfg = *s.history[i].cfg.Clone()
+	s.notify()
+	return nil
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestSafeConfigConcurrentReadersAndWriter(t *testing.T) {
+	const writes = 1000
+	s := NewSafeConfig(NewConfig("counter", WithValue(0)))
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range writes {
+				cfg := s.Get()
+				if cfg.Name != "counter" {
+					t.Errorf("Get().Name = %q, want %q", cfg.Name, "counter")
+					return
+				}
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range writes {
+			s.Update(func(c *Config) {
+				v, _ := c.AsInt64()
+				c.Value = v + 1
+			})
+		}
+	}()
+	wg.Wait()
+
+	if got := s.Get(); got.Value != int64(writes) {
+		t.Errorf("Value after %d updates = %v, want %d", writes, got.Value, writes)
+	}
+}
+
+func TestSafeConfigGetReturnsCopy(t *testing.T) {
+	s := NewSafeConfig(NewConfig("db", WithValue(1)))
+	cfg := s.Get()
+	cfg.Name = "changed"
+	if got := s.Get(); got.Name != "db" {
+		t.Errorf("Get().Name = %q after mutating a copy, want %q", got.Name, "db")
+	}
+}
+
+func TestSafeConfigWatchTwoWatchers(t *testing.T) {
+	const writes = 1000
+	s := NewSafeConfig(NewConfig("counter", WithValue(0)))
+	fast, stopFast := s.Watch()
+	slow, stopSlow := s.Watch()
+	defer stopSlow()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for cfg := range fast {
+			if cfg.Value == int64(writes) {
+				return
+			}
+		}
+		t.Error("fast watcher closed before seeing the last write")
+	}()
+	for i := range writes {
+		s.Set(NewConfig("counter", WithValue(int64(i+1))))
+	}
+	wg.Wait()
+	stopFast()
+	stopFast()
+
+	// The slow watcher never read, so it holds only the latest config.
+	if got := <-slow; got.Value != int64(writes) {
+		t.Errorf("slow watcher got %v, want the latest value %d", got.Value, writes)
+	}
+	select {
+	case got := <-slow:
+		t.Errorf("slow watcher has a second pending value %v", got.Value)
+	default:
+	}
+	if _, open := <-fast; open {
+		t.Error("channel still open after unsubscribe")
+	}
+	s.Set(NewConfig("counter"))
+}
+
+func TestSafeConfigHistoryDepth(t *testing.T) {
+	s := NewSafeConfig(NewConfig("v0"), WithHistoryDepth(3))
+	var ids []int
+	for i := range 5 {
+		s.Set(NewConfig("v", WithValue(int64(i))))
+		ids = append(ids, s.Snapshot())
+	}
+	for _, id := range ids[:2] {
+		if err := s.Rollback(id); !errors.Is(err, ErrUnknownSnapshot) {
+			t.Errorf("Rollback(%d) of an evicted snapshot = %v, want ErrUnknownSnapshot", id, err)
+		}
+	}
+	for i, id := range ids[2:] {
+		if err := s.Rollback(id); err != nil {
+			t.Errorf("Rollback(%d) of a retained snapshot: %v", id, err)
+		}
+		if got := s.Get(); got.Value != int64(i+2) {
+			t.Errorf("after Rollback(%d), Value = %v, want %d", id, got.Value, i+2)
+		}
+	}
+	if err := s.Rollback(99); !errors.Is(err, ErrUnknownSnapshot) {
+		t.Errorf("Rollback(99) = %v, want ErrUnknownSnapshot", err)
+	}
+}
+
+func TestSafeConfigRollbackRestoresIndependentCopy(t *testing.T) {
+	want := NewConfig("db", WithValue(5432), WithDescription("primary"))
+	s := NewSafeConfig(want)
+	id := s.Snapshot()
+	s.Set(NewStringConfig("other", "x"))
+
+	for range 2 {
+		if err := s.Rollback(id); err != nil {
+			t.Fatal(err)
+		}
+		if got := s.Get(); !got.Equal(want) {
+			t.Errorf("after Rollback, config = %v, want %v", &got, want)
+		}
+		s.Update(func(c *Config) {
+			c.Name = "edited"
+			c.Description = ""
+		})
+	}
+}
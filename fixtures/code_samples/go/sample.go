@@ -1,29 +1,195 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+)
 
 // Config holds application configuration.
+//
+// Value is one of int64, string, bool or float64; use the typed
+// accessors to read it back.
 type Config struct {
-	Name  string
-	Value int64
+	Name        string
+	Value       any
+	Description string
+
+	validators []ConfigValidator
+	sensitive  []string
+	present    []string
+	warnings   []string
+	derived    *derivedCache
+}
+
+// NewConfig creates a new Config whose Value starts at the default
+// registered for name (see SetDefaults), or int64 zero, then applies opts
+// in order so later options override earlier ones. An explicit
+// WithValue(0) therefore wins over a non-zero default.
+func NewConfig(name string, opts ...Option) *Config {
+	c := &Config{Name: name, Value: defaultValue(name)}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// NewConfigLegacy creates a new Config holding an int64 value.
+//
+// Deprecated: use NewConfig(name, WithValue(value)).
+func NewConfigLegacy(name string, value int64) *Config {
+	return NewConfig(name, WithValue(value))
+}
+
+// NewStringConfig creates a new Config holding a string value.
+func NewStringConfig(name, value string) *Config {
+	return &Config{Name: name, Value: value, present: []string{"Value"}}
+}
+
+// NewBoolConfig creates a new Config holding a bool value.
+func NewBoolConfig(name string, value bool) *Config {
+	return &Config{Name: name, Value: value, present: []string{"Value"}}
+}
+
+// NewFloatConfig creates a new Config holding a float64 value.
+func NewFloatConfig(name string, value float64) *Config {
+	return &Config{Name: name, Value: value, present: []string{"Value"}}
+}
+
+// Clone returns an independent copy of c. Reference-typed fields are
+// copied too, so mutating the clone never touches the source.
+func (c *Config) Clone() *Config {
+	return &Config{
+		Name:        c.Name,
+		Value:       c.Value,
+		Description: c.Description,
+		validators:  slices.Clone(c.validators),
+		sensitive:   slices.Clone(c.sensitive),
+		present:     slices.Clone(c.present),
+		warnings:    slices.Clone(c.warnings),
+	}
+}
+
+// Equal reports whether c and other hold the same field values, compared
+// by contents rather than address. Two nil configs are equal; nil and
+// non-nil are not. Attached validators are behaviour rather than data and
+// are not compared.
+func (c *Config) Equal(other *Config) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+	return c.Name == other.Name &&
+		valuesEqual(c.Value, other.Value) &&
+		c.Description == other.Description
+}
+
+// valuesEqual compares field values by contents. Value is an exported
+// any, so it can hold types such as slices that == would panic on.
+func valuesEqual(a, b any) bool {
+	return reflect.DeepEqual(a, b)
 }
 
-// NewConfig creates a new Config.
-func NewConfig(name string, value int64) *Config {
-	return &Config{Name: name, Value: value}
+// EqualExcept is Equal ignoring the named fields, given by Config field
+// name such as "Value". It panics on a name that matches no field, since
+// a typo would otherwise silently compare everything.
+func (c *Config) EqualExcept(other *Config, fields ...string) bool {
+	for _, name := range fields {
+		if !slices.ContainsFunc((&Config{}).fields(), func(f configField) bool { return f.name == name }) {
+			panic(fmt.Sprintf("config: EqualExcept: unknown field %q", name))
+		}
+	}
+	if c == nil || other == nil {
+		return c == other
+	}
+	otherFields := other.fields()
+	for i, f := range c.fields() {
+		if !slices.Contains(fields, f.name) && !valuesEqual(f.value, otherFields[i].value) {
+			return false
+		}
+	}
+	return true
 }
 
-// Display returns a formatted string.
+// AsInt64 returns the value if it is an int64.
+func (c *Config) AsInt64() (int64, bool) {
+	v, ok := c.Value.(int64)
+	return v, ok
+}
+
+// AsString returns the value if it is a string.
+func (c *Config) AsString() (string, bool) {
+	v, ok := c.Value.(string)
+	return v, ok
+}
+
+// AsBool returns the value if it is a bool.
+func (c *Config) AsBool() (bool, bool) {
+	v, ok := c.Value.(bool)
+	return v, ok
+}
+
+// AsFloat64 returns the value if it is a float64.
+func (c *Config) AsFloat64() (float64, bool) {
+	v, ok := c.Value.(float64)
+	return v, ok
+}
+
+// String formats the config as "name: value", implementing fmt.Stringer.
+// Sensitive fields are shown as "****" and a nil config formats as
+// "<nil config>".
+func (c *Config) String() string {
+	return c.format(true)
+}
+
+// DisplayUnsafe is String without redaction, for debugging.
+func (c *Config) DisplayUnsafe() string {
+	return c.format(false)
+}
+
+// format renders "name: value", optionally redacting sensitive fields.
+func (c *Config) format(redact bool) string {
+	if c == nil {
+		return "<nil config>"
+	}
+	name, value := c.Name, formatValue(c.Value)
+	if redact && c.isSensitive("Name") {
+		name = redactedText
+	}
+	if redact && c.isSensitive("Value") {
+		value = redactedText
+	}
+	return fmt.Sprintf("%s: %s", name, value)
+}
+
+// Display returns a formatted string. It is equivalent to String.
 func (c *Config) Display() string {
-	return fmt.Sprintf("%s: %d", c.Name, c.Value)
+	return c.String()
+}
+
+// formatValue renders a config value: strings quoted, everything else bare.
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case string:
+		return strconv.Quote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
 }
 
 func helper(x int) int {
-	return x * 2
+	return Double(x)
 }
 
 func main() {
-	cfg := NewConfig("test", 42)
-	fmt.Println(cfg.Display())
+	cfg := NewConfig("test", WithValue(42))
+	fmt.Println(cfg)
+	fmt.Println(NewStringConfig("host", "localhost"))
 	fmt.Println(helper(10))
 }
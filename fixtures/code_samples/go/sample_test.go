@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCloneIsIndependent(t *testing.T) {
+	orig := NewConfig("db", WithValue(5432), WithDescription("primary"))
+	clone := orig.Clone()
+	clone.Name = "cache"
+	clone.Value = int64(6379)
+	clone.Description = ""
+	if want := NewConfig("db", WithValue(5432), WithDescription("primary")); !orig.Equal(want) {
+		t.Errorf("mutating the clone changed the original to %v", orig)
+	}
+}
+
+func TestCloneDeepCopiesSlices(t *testing.T) {
+	orig := NewConfig("db", WithValue(5432), WithSensitiveFields("Value"))
+	clone := orig.Clone()
+	clone.sensitive[0] = "Name"
+	WithSensitiveFields("Description")(clone)
+	if len(orig.sensitive) != 1 || orig.sensitive[0] != "Value" {
+		t.Errorf("original sensitive fields = %v after mutating the clone, want [Value]", orig.sensitive)
+	}
+	if got := orig.String(); got != "db: ****" {
+		t.Errorf("original String() = %q, want %q", got, "db: ****")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	var nilCfg *Config
+	cfg := NewConfig("db", WithValue(5432), WithDescription("primary"))
+	tests := []struct {
+		name string
+		a, b *Config
+		want bool
+	}{
+		{"both nil", nil, nil, true},
+		{"nil receiver", nilCfg, cfg, false},
+		{"nil argument", cfg, nil, false},
+		{"same pointer", cfg, cfg, true},
+		{"identical", cfg, NewConfig("db", WithValue(5432), WithDescription("primary")), true},
+		{"differ only in Value", cfg, NewConfig("db", WithValue(5433), WithDescription("primary")), false},
+		{"differ in Value type", NewConfig("n", WithValue(1)), NewFloatConfig("n", 1), false},
+		{"differ in Description", cfg, NewConfig("db", WithValue(5432)), false},
+		{"validators ignored", cfg, NewConfig("db", WithValue(5432), WithDescription("primary"), WithValidators(InRange(0, 1))), true},
+		{"equal slice values", &Config{Name: "s", Value: []int{1, 2}}, &Config{Name: "s", Value: []int{1, 2}}, true},
+		{"different slice values", &Config{Name: "s", Value: []int{1, 2}}, &Config{Name: "s", Value: []int{1}}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Equal(tt.b); got != tt.want {
+			t.Errorf("%s: Equal = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestUncomparableValuesDoNotPanic(t *testing.T) {
+	a := &Config{Name: "s", Value: []int{1}}
+	b := &Config{Name: "s", Value: []int{2}}
+	if a.EqualExcept(b, "Name") {
+		t.Error("EqualExcept ignoring Name reported different slices equal")
+	}
+	if changes := Diff(a, b); len(changes) != 1 || changes[0].Field != "Value" {
+		t.Errorf("Diff = %v, want one Value change", changes)
+	}
+	if DiffText(a, b) == "" {
+		t.Error("DiffText of different slices is empty")
+	}
+}
+
+func TestEqualExcept(t *testing.T) {
+	a := NewConfig("db", WithValue(5432), WithDescription("primary"))
+	tests := []struct {
+		name   string
+		b      *Config
+		ignore []string
+		want   bool
+	}{
+		{"ignore Value, names differ", NewConfig("cache", WithValue(6379), WithDescription("primary")), []string{"Value"}, false},
+		{"ignore Value, names match", NewConfig("db", WithValue(6379), WithDescription("primary")), []string{"Value"}, true},
+		{"ignore nothing", NewConfig("db", WithValue(6379), WithDescription("primary")), nil, false},
+		{"ignore Name and Description", NewConfig("cache", WithValue(5432)), []string{"Name", "Description"}, true},
+		{"nil argument", nil, []string{"Value"}, false},
+	}
+	for _, tt := range tests {
+		if got := a.EqualExcept(tt.b, tt.ignore...); got != tt.want {
+			t.Errorf("%s: EqualExcept(%q) = %v, want %v", tt.name, tt.ignore, got, tt.want)
+		}
+	}
+}
+
+func TestEqualExceptUnknownFieldPanics(t *testing.T) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("EqualExcept with an unknown field did not panic")
+		}
+		if msg := fmt.Sprint(r); !strings.Contains(msg, `"Valeu"`) {
+			t.Errorf("panic %q does not name the field", msg)
+		}
+	}()
+	cfg := NewConfig("db")
+	cfg.EqualExcept(cfg.Clone(), "Valeu")
+}
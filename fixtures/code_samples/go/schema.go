@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+// schemaProperty describes one key of the Config JSON form.
+type schemaProperty struct {
+	name     string
+	types    []string
+	required bool
+}
+
+// schemaProperties derives the JSON properties from configJSON's fields
+// and tags, so the schema follows the wire format as fields are added.
+func schemaProperties() []schemaProperty {
+	t := reflect.TypeFor[configJSON]()
+	props := make([]schemaProperty, 0, t.NumField())
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, flags, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		prop := schemaProperty{name: name, required: !strings.Contains(flags, "omitempty")}
+		if tag := field.Tag.Get("schema"); tag != "" {
+			prop.types = strings.Split(tag, ",")
+		} else {
+			prop.types = []string{jsonType(field.Type)}
+		}
+		props = append(props, prop)
+	}
+	return props
+}
+
+// jsonType maps a Go type to its JSON Schema type.
+func jsonType(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// GenerateJSONSchema returns a JSON Schema (draft 2020-12) describing the
+// JSON form of a Config, indented for reading. Unknown keys are allowed
+// because loaders ignore them and accept deprecated aliases.
+func GenerateJSONSchema() []byte {
+	properties := make(map[string]any)
+	required := []string{}
+	for _, prop := range schemaProperties() {
+		var typ any = prop.types[0]
+		if len(prop.types) > 1 {
+			typ = prop.types
+		}
+		properties[prop.name] = map[string]any{"type": typ}
+		if prop.required {
+			required = append(required, prop.name)
+		}
+	}
+	schema := map[string]any{
+		"$schema":    "https://json-schema.org/draft/2020-12/schema",
+		"title":      "Config",
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		panic(fmt.Sprintf("config: encoding JSON schema: %v", err))
+	}
+	return append(data, '\n')
+}
+
+// ValidateAgainstSchema checks data against the schema GenerateJSONSchema
+// describes, joining one error per violation.
+func ValidateAgainstSchema(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return fmt.Errorf("schema: %w", err)
+	}
+	obj, ok := doc.(map[string]any)
+	if !ok {
+		return fmt.Errorf("schema: want a JSON object, got %s", schemaTypeOf(doc))
+	}
+	var errs []error
+	for _, prop := range schemaProperties() {
+		value, present := obj[prop.name]
+		switch {
+		case !present && prop.required:
+			errs = append(errs, fmt.Errorf("schema: missing required property %q", prop.name))
+		case present && !schemaTypeMatches(value, prop.types):
+			errs = append(errs, fmt.Errorf("schema: property %q: %w: want %s, got %s",
+				prop.name, ErrInvalidValue, strings.Join(prop.types, " or "), schemaTypeOf(value)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// schemaTypeMatches reports whether v has one of types. An integral number
+// is both an integer and a number.
+func schemaTypeMatches(v any, types []string) bool {
+	actual := schemaTypeOf(v)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// schemaTypeOf names the JSON Schema type of a decoded value.
+func schemaTypeOf(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if f, ok := new(big.Float).SetString(v.String()); ok && f.IsInt() {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestGenerateJSONSchemaGolden(t *testing.T) {
+	checkGolden(t, "schema.golden.json", string(GenerateJSONSchema()))
+}
+
+func TestValidateAgainstSchemaAgreesWithLoader(t *testing.T) {
+	docs := []string{
+		`{"name":"x"}`,
+		`{"name":"x","value":42}`,
+		`{"name":"x","value":1.5}`,
+		`{"name":"x","value":"on"}`,
+		`{"name":"x","value":true}`,
+		`{"name":"x","value":null}`,
+		`{"name":"x","value":1,"description":null}`,
+		`{"name":"x","unknown":[1,2]}`,
+	}
+	for _, doc := range docs {
+		if err := ValidateAgainstSchema([]byte(doc)); err != nil {
+			t.Errorf("ValidateAgainstSchema(%s): %v", doc, err)
+		}
+		var cfg Config
+		if err := json.Unmarshal([]byte(doc), &cfg); err != nil {
+			t.Errorf("json.Unmarshal(%s): %v", doc, err)
+		}
+	}
+}
+
+func TestValidateAgainstSchemaBadDocument(t *testing.T) {
+	bad := []byte(`{"value":[1],"description":7}`)
+	err := ValidateAgainstSchema(bad)
+	if err == nil {
+		t.Fatalf("ValidateAgainstSchema(%s) succeeded", bad)
+	}
+	if !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("error %v does not wrap ErrInvalidValue", err)
+	}
+	if n := len(err.(interface{ Unwrap() []error }).Unwrap()); n != 3 {
+		t.Errorf("got %d violations, want 3 (missing name, bad value, bad description): %v", n, err)
+	}
+	for _, doc := range []string{`[]`, `"x"`, `{"name":null}`, `{"name":1}`, `{`} {
+		if err := ValidateAgainstSchema([]byte(doc)); err == nil {
+			t.Errorf("ValidateAgainstSchema(%s) succeeded", doc)
+		}
+	}
+}
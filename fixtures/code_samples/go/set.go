@@ -0,0 +1,97 @@
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ConfigSet is a collection of configs with unique names. Its sort.Interface
+// orders by Name; wrap it in ByValue to order by Value instead.
+type ConfigSet []*Config
+
+// Get returns the config called name. Nil entries are skipped.
+func (s ConfigSet) Get(name string) (*Config, bool) {
+	for _, c := range s {
+		if c != nil && c.Name == name {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// Add appends c, refusing a nil config and one whose name is already in
+// the set.
+func (s *ConfigSet) Add(c *Config) error {
+	if c == nil {
+		return errors.New("config set: cannot add a nil config")
+	}
+	if _, ok := s.Get(c.Name); ok {
+		return fmt.Errorf("%w %q in set", ErrDuplicateName, c.Name)
+	}
+	*s = append(*s, c)
+	return nil
+}
+
+// Names returns the config names in sorted order.
+func (s ConfigSet) Names() []string {
+	names := make([]string, len(s))
+	for i, c := range s {
+		names[i] = c.Name
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s ConfigSet) Len() int           { return len(s) }
+func (s ConfigSet) Less(i, j int) bool { return s[i].Name < s[j].Name }
+func (s ConfigSet) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// ByValue sorts a ConfigSet by Value: int64 values numerically, followed by
+// any other values ordered by their formatted text.
+type ByValue struct{ ConfigSet }
+
+func (s ByValue) Less(i, j int) bool {
+	return compareValues(s.ConfigSet[i].Value, s.ConfigSet[j].Value) < 0
+}
+
+// compareValues orders int64 values numerically, before any other values,
+// which are ordered by their formatted text.
+func compareValues(a, b any) int {
+	x, xInt := a.(int64)
+	y, yInt := b.(int64)
+	switch {
+	case xInt && yInt:
+		return cmp.Compare(x, y)
+	case xInt:
+		return -1
+	case yInt:
+		return 1
+	default:
+		return cmp.Compare(formatValue(a), formatValue(b))
+	}
+}
+
+// Map returns a new set holding fn applied to a clone of each config, so
+// fn may modify its argument without touching the originals. Map and
+// Filter compose, e.g. s.Filter(keep).Map(double).
+func (s ConfigSet) Map(fn func(*Config) *Config) ConfigSet {
+	out := make(ConfigSet, len(s))
+	for i, c := range s {
+		out[i] = fn(c.Clone())
+	}
+	return out
+}
+
+// Filter returns a new set holding the configs for which keep is true.
+// The configs themselves are shared with s, not copied.
+func (s ConfigSet) Filter(keep func(*Config) bool) ConfigSet {
+	var out ConfigSet
+	for _, c := range s {
+		if keep(c) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestConfigSetAddRejectsDuplicates(t *testing.T) {
+	var s ConfigSet
+	if err := s.Add(NewConfig("db", WithValue(1))); err != nil {
+		t.Fatal(err)
+	}
+	err := s.Add(NewConfig("db", WithValue(2)))
+	if !errors.Is(err, ErrDuplicateName) {
+		t.Errorf("Add of a duplicate = %v, want ErrDuplicateName", err)
+	}
+	if got, _ := s.Get("db"); got.Value != int64(1) {
+		t.Errorf("duplicate Add overwrote the original: %v", got)
+	}
+	if err := s.Add(nil); err == nil {
+		t.Error("Add(nil) succeeded")
+	}
+	if len(s) != 1 {
+		t.Errorf("set has %d configs, want 1", len(s))
+	}
+}
+
+func TestConfigSetGet(t *testing.T) {
+	s := ConfigSet{nil, NewConfig("db", WithValue(5432))}
+	if got, ok := s.Get("db"); !ok || got.Value != int64(5432) {
+		t.Errorf("Get(%q) = %v, %v", "db", got, ok)
+	}
+	if got, ok := s.Get("missing"); ok || got != nil {
+		t.Errorf("Get(%q) = %v, %v; want nil, false", "missing", got, ok)
+	}
+}
+
+func TestConfigSetSort(t *testing.T) {
+	s := ConfigSet{
+		NewConfig("b", WithValue(3)),
+		NewStringConfig("c", "x"),
+		NewConfig("a", WithValue(-1)),
+	}
+	if got := s.Names(); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("Names() = %v", got)
+	}
+	sort.Sort(s)
+	if got := []string{s[0].Name, s[1].Name, s[2].Name}; !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("sorted by name = %v", got)
+	}
+	sort.Sort(ByValue{s})
+	if got := []string{s[0].Name, s[1].Name, s[2].Name}; !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("sorted by value = %v, want int64 values ascending then others", got)
+	}
+	s[0].Value = int64(10)
+	sort.Sort(ByValue{s})
+	if got := []string{s[0].Name, s[1].Name, s[2].Name}; !slices.Equal(got, []string{"b", "a", "c"}) {
+		t.Errorf("sorted by value = %v, want [b a c]", got)
+	}
+}
+
+func double(c *Config) *Config {
+	v, _ := c.AsInt64()
+	c.Value = v * 2
+	return c
+}
+
+func TestConfigSetMapDoesNotMutate(t *testing.T) {
+	s := ConfigSet{NewConfig("a", WithValue(1)), NewConfig("b", WithValue(2))}
+	got := s.Map(double)
+	want := ConfigSet{NewConfig("a", WithValue(2)), NewConfig("b", WithValue(4))}
+	if !slices.EqualFunc(got, want, (*Config).Equal) {
+		t.Errorf("Map(double) = %v, want %v", got, want)
+	}
+	if s[0].Value != int64(1) || s[1].Value != int64(2) {
+		t.Errorf("Map modified the originals: %v", s)
+	}
+}
+
+func TestConfigSetFilterThenMap(t *testing.T) {
+	s := ConfigSet{NewConfig("a", WithValue(1)), NewConfig("b", WithValue(2)), NewConfig("c", WithValue(3))}
+	odd := func(c *Config) bool {
+		v, _ := c.AsInt64()
+		return v%2 == 1
+	}
+	got := s.Filter(odd).Map(double)
+	want := ConfigSet{NewConfig("a", WithValue(2)), NewConfig("c", WithValue(6))}
+	if !slices.EqualFunc(got, want, (*Config).Equal) {
+		t.Errorf("Filter(odd).Map(double) = %v, want %v", got, want)
+	}
+	if s[0].Value != int64(1) || s[2].Value != int64(3) {
+		t.Errorf("Filter then Map modified the originals: %v", s)
+	}
+	if len(s.Filter(func(*Config) bool { return false })) != 0 {
+		t.Error("Filter rejecting everything returned configs")
+	}
+}
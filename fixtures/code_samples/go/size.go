@@ -0,0 +1,137 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// sizeUnit is a byte-size suffix and its multiplier.
+type sizeUnit struct {
+	suffix string
+	factor uint64
+}
+
+// siUnits and binaryUnits are ordered largest first.
+var (
+	siUnits = []sizeUnit{
+		{"EB", 1e18}, {"PB", 1e15}, {"TB", 1e12}, {"GB", 1e9}, {"MB", 1e6}, {"KB", 1e3},
+	}
+	binaryUnits = []sizeUnit{
+		{"EiB", 1 << 60}, {"PiB", 1 << 50}, {"TiB", 1 << 40}, {"GiB", 1 << 30}, {"MiB", 1 << 20}, {"KiB", 1 << 10},
+	}
+)
+
+// NewConfigFromSize creates a Config whose Value is the byte count of s.
+//
+// s is a number, optionally negative, with an optional suffix: B, the SI units
+// KB, MB, GB, TB, PB and EB (powers of 1000), or the binary units KiB,
+// MiB, GiB, TiB, PiB and EiB (powers of 1024), which may drop the trailing
+// "B" as in "512Mi". Suffixes are case-insensitive. Fractional sizes are
+// rounded to the nearest byte, halves away from zero, so "1.5GB" is
+// 1500000000 and "1.5B" is 2.
+func NewConfigFromSize(name, s string) (*Config, error) {
+	n, err := parseSize(s)
+	if err != nil {
+		return nil, fmt.Errorf("config %q: %w", name, &ParseError{Field: "Value", Raw: s, Err: err})
+	}
+	return NewConfig(name, WithValue(n)), nil
+}
+
+// HumanValue renders an int64 Value as a byte size using the exact unit
+// with the smallest mantissa, such as "10GiB" or "1500MB", so the result
+// parses back to the same count. Sizes that fit no unit are shown in
+// bytes; non-int64 values are formatted as in String.
+func (c *Config) HumanValue() string {
+	v, ok := c.AsInt64()
+	if !ok {
+		return formatValue(c.Value)
+	}
+	sign, n := "", uint64(v)
+	if v < 0 {
+		sign, n = "-", -uint64(v)
+	}
+	best := strconv.FormatUint(n, 10) + "B"
+	bestMantissa := n
+	for _, units := range [][]sizeUnit{binaryUnits, siUnits} {
+		for _, u := range units {
+			if n != 0 && n%u.factor == 0 {
+				if m := n / u.factor; m < bestMantissa {
+					best, bestMantissa = strconv.FormatUint(m, 10)+u.suffix, m
+				}
+				break
+			}
+		}
+	}
+	return sign + best
+}
+
+// parseSize converts a size string to a byte count.
+func parseSize(s string) (int64, error) {
+	trimmed := strings.TrimSpace(s)
+	negative := strings.HasPrefix(trimmed, "-")
+	if negative {
+		trimmed = trimmed[1:]
+	}
+	limit := uint64(math.MaxInt64)
+	if negative {
+		limit++
+	}
+	end := strings.IndexFunc(trimmed, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if end < 0 {
+		end = len(trimmed)
+	}
+	number, suffix := trimmed[:end], strings.TrimSpace(trimmed[end:])
+	if number == "" {
+		return 0, errors.New("invalid size")
+	}
+	factor, ok := sizeFactor(suffix)
+	if !ok {
+		return 0, fmt.Errorf("unknown suffix %q", suffix)
+	}
+	if !strings.Contains(number, ".") {
+		n, err := strconv.ParseUint(number, 10, 64)
+		if err != nil || n > limit/factor {
+			return 0, errors.New("size overflows int64")
+		}
+		if negative {
+			return -int64(n * factor), nil
+		}
+		return int64(n * factor), nil
+	}
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, errors.New("invalid size")
+	}
+	bytes := math.Round(f * float64(factor))
+	if negative {
+		bytes = -bytes
+	}
+	if bytes >= math.MaxInt64 || bytes < math.MinInt64 {
+		return 0, errors.New("size overflows int64")
+	}
+	return int64(bytes), nil
+}
+
+// sizeFactor looks up the multiplier for a suffix; "" and "B" mean bytes.
+func sizeFactor(suffix string) (uint64, bool) {
+	upper := strings.ToUpper(suffix)
+	if upper == "" || upper == "B" {
+		return 1, true
+	}
+	for _, u := range siUnits {
+		if upper == u.suffix {
+			return u.factor, true
+		}
+	}
+	for _, u := range binaryUnits {
+		if full := strings.ToUpper(u.suffix); upper == full || upper == strings.TrimSuffix(full, "B") {
+			return u.factor, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewConfigFromSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"0", 0},
+		{"1024", 1024},
+		{"1KiB", 1024},
+		{"1.5GB", 1500000000},
+		{"512Mi", 512 << 20},
+		{"10 gib", 10 << 30},
+		{"1.5B", 2},
+		{"-2KiB", -2048},
+		{"-8EiB", math.MinInt64},
+	}
+	for _, tt := range tests {
+		cfg, err := NewConfigFromSize("size", tt.in)
+		if err != nil {
+			t.Errorf("NewConfigFromSize(%q): %v", tt.in, err)
+			continue
+		}
+		if cfg.Value != tt.want {
+			t.Errorf("NewConfigFromSize(%q) = %v, want %d", tt.in, cfg.Value, tt.want)
+		}
+	}
+}
+
+func TestNewConfigFromSizeInvalid(t *testing.T) {
+	for _, in := range []string{"", "KiB", "-", "--1", "1XB", "1.2.3MB", "8EiB", "99999999999999999999"} {
+		if cfg, err := NewConfigFromSize("size", in); err == nil {
+			t.Errorf("NewConfigFromSize(%q) = %v, want error", in, cfg.Value)
+		}
+	}
+}
+
+func TestHumanValueRoundTrip(t *testing.T) {
+	tests := []struct {
+		value int64
+		want  string
+	}{
+		{0, "0B"},
+		{1000, "1KB"},
+		{1024, "1KiB"},
+		{1500000000, "1500MB"},
+		{10 << 30, "10GiB"},
+		{1001, "1001B"},
+		{-2048, "-2KiB"},
+		{math.MinInt64, "-8EiB"},
+	}
+	for _, tt := range tests {
+		got := NewConfig("size", WithValue(tt.value)).HumanValue()
+		if got != tt.want {
+			t.Errorf("HumanValue(%d) = %q, want %q", tt.value, got, tt.want)
+		}
+		back, err := NewConfigFromSize("size", got)
+		if err != nil || back.Value != tt.value {
+			t.Errorf("NewConfigFromSize(%q) = %v, %v; want %d", got, back, err, tt.value)
+		}
+	}
+}
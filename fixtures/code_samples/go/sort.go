@@ -0,0 +1,49 @@
+package main
+
+import (
+	"cmp"
+	"slices"
+)
+
+// SortKey compares two non-nil configs for SortConfigs, returning a
+// negative number, zero or a positive number as a sorts before, with or
+// after b.
+type SortKey func(a, b *Config) int
+
+var (
+	// ByName orders configs by Name.
+	ByName SortKey = func(a, b *Config) int { return cmp.Compare(a.Name, b.Name) }
+	// ByValueAsc orders configs by Value as ByValue does.
+	ByValueAsc SortKey = func(a, b *Config) int { return compareValues(a.Value, b.Value) }
+	// ByValueDesc is ByValueAsc reversed.
+	ByValueDesc SortKey = func(a, b *Config) int { return compareValues(b.Value, a.Value) }
+)
+
+// SortConfigs sorts cfgs in place by the given keys, each breaking ties
+// left by the ones before it, so SortConfigs(cfgs, ByValueAsc, ByName)
+// sorts by Value and then Name. The sort is stable, nil entries go last
+// and with no keys the configs are sorted by Name.
+func SortConfigs(cfgs []*Config, by ...SortKey) {
+	if len(by) == 0 {
+		by = []SortKey{ByName}
+	}
+	slices.SortStableFunc(cfgs, func(a, b *Config) int {
+		if a == nil || b == nil {
+			return cmp.Compare(boolRank(a == nil), boolRank(b == nil))
+		}
+		for _, key := range by {
+			if c := key(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+}
+
+// boolRank orders false before true.
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
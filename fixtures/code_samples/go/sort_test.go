@@ -0,0 +1,61 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// configNames returns the names of cfgs, with "<nil>" for nil entries.
+func configNames(cfgs []*Config) []string {
+	names := make([]string, len(cfgs))
+	for i, c := range cfgs {
+		names[i] = "<nil>"
+		if c != nil {
+			names[i] = c.Name
+		}
+	}
+	return names
+}
+
+func TestSortConfigsTieBreak(t *testing.T) {
+	cfgs := []*Config{
+		NewConfig("c", WithValue(2)),
+		NewConfig("b", WithValue(1)),
+		NewConfig("a", WithValue(2)),
+		NewStringConfig("s", "text"),
+		NewConfig("d", WithValue(1)),
+	}
+	tests := []struct {
+		name string
+		by   []SortKey
+		want []string
+	}{
+		{"no keys", nil, []string{"a", "b", "c", "d", "s"}},
+		{"value asc, name", []SortKey{ByValueAsc, ByName}, []string{"b", "d", "a", "c", "s"}},
+		{"value desc, name", []SortKey{ByValueDesc, ByName}, []string{"s", "a", "c", "b", "d"}},
+	}
+	for _, tt := range tests {
+		got := slices.Clone(cfgs)
+		SortConfigs(got, tt.by...)
+		if names := configNames(got); !slices.Equal(names, tt.want) {
+			t.Errorf("%s: SortConfigs = %v, want %v", tt.name, names, tt.want)
+		}
+	}
+}
+
+func TestSortConfigsStable(t *testing.T) {
+	first, second := NewConfig("x", WithValue(1)), NewConfig("y", WithValue(1))
+	cfgs := []*Config{first, NewConfig("z", WithValue(0)), second}
+	SortConfigs(cfgs, ByValueAsc)
+	if cfgs[1] != first || cfgs[2] != second {
+		t.Errorf("SortConfigs(ByValueAsc) = %v, want ties in input order", configNames(cfgs))
+	}
+}
+
+func TestSortConfigsNilLast(t *testing.T) {
+	cfgs := []*Config{nil, NewConfig("b"), nil, NewConfig("a")}
+	SortConfigs(cfgs, ByValueDesc, ByName)
+	if names := configNames(cfgs); !slices.Equal(names, []string{"a", "b", "<nil>", "<nil>"}) {
+		t.Errorf("SortConfigs = %v, want nil entries last", names)
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// DecodeStream yields one Config per line of newline-delimited JSON,
+// decoded as LoadJSON does with opts.
+//
+// Blank lines are skipped. A line that fails to parse yields a nil config
+// and an error naming the line, then decoding carries on with the next
+// line; only a read error from r ends the stream early.
+func DecodeStream(r io.Reader, opts ...MapOption) iter.Seq2[*Config, error] {
+	o := newMapOptions(opts)
+	return func(yield func(*Config, error) bool) {
+		br := bufio.NewReader(r)
+		for line := 1; ; line++ {
+			data, readErr := br.ReadBytes('\n')
+			if readErr != nil && !errors.Is(readErr, io.EOF) {
+				yield(nil, fmt.Errorf("config stream: line %d: %w", line, readErr))
+				return
+			}
+			if data = bytes.TrimSpace(data); len(data) > 0 {
+				cfg, err := decodeJSON(data, o)
+				if err != nil {
+					if !yield(nil, fmt.Errorf("config stream: line %d: %w", line, err)) {
+						return
+					}
+				} else if !yield(cfg, nil) {
+					return
+				}
+			}
+			if readErr != nil {
+				return
+			}
+		}
+	}
+}
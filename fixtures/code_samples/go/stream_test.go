@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDecodeStreamMixedLines(t *testing.T) {
+	input := strings.Join([]string{
+		`{"name":"a","value":1}`,
+		``,
+		`not json`,
+		`   `,
+		`{"value":2}`,
+		`{"name":"b","value":3}`,
+		`{"name":"c"}`, // no trailing newline
+	}, "\n")
+
+	var names []string
+	var errs []error
+	for cfg, err := range DecodeStream(strings.NewReader(input)) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		names = append(names, cfg.Name)
+	}
+	if strings.Join(names, ",") != "a,b,c" {
+		t.Errorf("decoded %v, want [a b c]", names)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("got %d errors, want 2: %v", len(errs), errs)
+	}
+	if !strings.Contains(errs[0].Error(), "line 3") || !strings.Contains(errs[1].Error(), "line 5") {
+		t.Errorf("errors %v do not name lines 3 and 5", errs)
+	}
+	if !errors.Is(errs[1], ErrMissingName) {
+		t.Errorf("error %v does not wrap ErrMissingName", errs[1])
+	}
+}
+
+func TestDecodeStreamStopsWhenAsked(t *testing.T) {
+	input := "{\"name\":\"a\"}\n{\"name\":\"b\"}\n"
+	n := 0
+	for range DecodeStream(strings.NewReader(input)) {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Errorf("loop ran %d times after break, want 1", n)
+	}
+}
+
+// failingReader returns data and then a non-EOF error.
+type failingReader struct{ data string }
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.data == "" {
+		return 0, errors.New("disk on fire")
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func TestDecodeStreamReadError(t *testing.T) {
+	var got []string
+	for cfg, err := range DecodeStream(&failingReader{data: "{\"name\":\"a\"}\n"}) {
+		if err != nil {
+			got = append(got, "error: "+err.Error())
+			continue
+		}
+		got = append(got, cfg.Name)
+	}
+	if len(got) != 2 || got[0] != "a" || !strings.Contains(got[1], "disk on fire") {
+		t.Errorf("DecodeStream yielded %q, want the config then the read error", got)
+	}
+}
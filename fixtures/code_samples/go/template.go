@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// RenderName executes tmpl, such as "{{.Env}}-db", against data with
+// text/template. Missing map keys are errors rather than "<no value>".
+func RenderName(tmpl string, data any) (string, error) {
+	return renderField("Name", tmpl, data)
+}
+
+// RenderInto returns a new Config with every string field (Name,
+// Description and a string Value) rendered as a template against data.
+// The receiver is left untouched.
+func (c *Config) RenderInto(data any) (*Config, error) {
+	out := c.Clone()
+	var err error
+	if out.Name, err = renderField("Name", c.Name, data); err != nil {
+		return nil, err
+	}
+	if out.Description, err = renderField("Description", c.Description, data); err != nil {
+		return nil, err
+	}
+	if s, ok := c.AsString(); ok {
+		if out.Value, err = renderField("Value", s, data); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// renderField executes text as a template, naming field in any error.
+func renderField(field, text string, data any) (string, error) {
+	t, err := template.New(field).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("config: parsing %s template: %w", field, err)
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("config: rendering %s template: %w", field, err)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderName(t *testing.T) {
+	got, err := RenderName("{{.Env}}-db", map[string]string{"Env": "prod"})
+	if err != nil || got != "prod-db" {
+		t.Errorf("RenderName = %q, %v; want %q", got, err, "prod-db")
+	}
+	got, err = RenderName("{{.Env}}-db", struct{ Env string }{"dev"})
+	if err != nil || got != "dev-db" {
+		t.Errorf("RenderName with a struct = %q, %v; want %q", got, err, "dev-db")
+	}
+}
+
+func TestRenderNameMissingKey(t *testing.T) {
+	_, err := RenderName("{{.Env}}-db", map[string]string{})
+	if err == nil || !strings.Contains(err.Error(), "Name") {
+		t.Errorf("RenderName with a missing key = %v, want an error naming the Name field", err)
+	}
+	if _, err := RenderName("{{.Env", nil); err == nil {
+		t.Error("RenderName of a malformed template succeeded")
+	}
+}
+
+func TestRenderInto(t *testing.T) {
+	data := map[string]string{"Env": "prod", "Host": "db.internal"}
+	orig := &Config{Name: "{{.Env}}-db", Value: "{{.Host}}:5432", Description: "{{.Env}} database"}
+	got, err := orig.RenderInto(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Config{Name: "prod-db", Value: "db.internal:5432", Description: "prod database"}
+	if !got.Equal(want) {
+		t.Errorf("RenderInto = %#v, want %#v", got, want)
+	}
+	if orig.Name != "{{.Env}}-db" {
+		t.Errorf("RenderInto modified the receiver: %v", orig)
+	}
+
+	bad := &Config{Name: "db", Value: int64(1), Description: "{{.Missing}}"}
+	if _, err := bad.RenderInto(data); err == nil || !strings.Contains(err.Error(), "Description") {
+		t.Errorf("RenderInto with a missing key = %v, want an error naming Description", err)
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// LoadTOML reads a Config from a TOML document with top-level name and
+// value keys. value may be an integer or a string holding one.
+//
+// As with LoadYAML, only the flat subset Config needs is understood:
+// "key = value" pairs of basic strings, literal strings and integers, plus
+// comments. Tables and arrays are rejected.
+func LoadTOML(r io.Reader, opts ...MapOption) (*Config, error) {
+	fields := make(map[string]any)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		key, raw, ok := strings.Cut(text, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("toml: line %d: expected \"key = value\"", line)
+		}
+		if _, dup := fields[key]; dup {
+			return nil, fmt.Errorf("toml: line %d: duplicate key %q", line, key)
+		}
+		value, err := parseTOMLValue(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("toml: line %d: %w", line, err)
+		}
+		fields[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("toml: %w", err)
+	}
+	return FromMap(fields, opts...)
+}
+
+// SaveTOML writes cfg as TOML with keys in a fixed order (name, value,
+// then description if set) so the output diffs cleanly.
+func SaveTOML(w io.Writer, cfg *Config) error {
+	value, err := cfg.int64Value()
+	if err != nil {
+		return fmt.Errorf("toml: %w", err)
+	}
+	if _, err := fmt.Fprintf(w, "name = %s\nvalue = %d\n", quoteTOML(cfg.Name), value); err != nil {
+		return err
+	}
+	if cfg.Description == "" {
+		return nil
+	}
+	_, err = fmt.Fprintf(w, "description = %s\n", quoteTOML(cfg.Description))
+	return err
+}
+
+// parseTOMLValue decodes a string or integer, allowing a trailing comment.
+func parseTOMLValue(raw string) (any, error) {
+	var value any
+	var rest string
+	switch {
+	case strings.HasPrefix(raw, `"`):
+		end := closingQuote(raw)
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated string %s", raw)
+		}
+		s, err := strconv.Unquote(raw[:end+1])
+		if err != nil {
+			return nil, fmt.Errorf("malformed string %s", raw[:end+1])
+		}
+		value, rest = s, raw[end+1:]
+	case strings.HasPrefix(raw, "'"):
+		end := strings.IndexByte(raw[1:], '\'')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated string %s", raw)
+		}
+		value, rest = raw[1:end+1], raw[end+2:]
+	default:
+		literal, _, _ := strings.Cut(raw, "#")
+		literal = strings.TrimSpace(literal)
+		if literal == "" || !strings.ContainsAny(literal[:1], "+-0123456789") {
+			return nil, fmt.Errorf("unsupported value %q", literal)
+		}
+		return parseTOMLInt(literal)
+	}
+	if rest = strings.TrimSpace(rest); rest != "" && !strings.HasPrefix(rest, "#") {
+		return nil, fmt.Errorf("unexpected %q after value", rest)
+	}
+	return value, nil
+}
+
+// parseTOMLInt parses an integer by TOML's rules rather than Go's: a
+// decimal with an optional sign and no leading zeros, or an unsigned
+// 0x, 0o or 0b literal. Underscores may separate digits.
+func parseTOMLInt(literal string) (int64, error) {
+	base, digits := 10, strings.TrimLeft(literal, "+-")
+	switch {
+	case len(literal)-len(digits) > 1:
+		return 0, fmt.Errorf("malformed integer %q", literal)
+	case strings.HasPrefix(literal, "0x"):
+		base, digits = 16, literal[2:]
+	case strings.HasPrefix(literal, "0o"):
+		base, digits = 8, literal[2:]
+	case strings.HasPrefix(literal, "0b"):
+		base, digits = 2, literal[2:]
+	case len(digits) > 1 && digits[0] == '0':
+		return 0, fmt.Errorf("leading zero in integer %q", literal)
+	}
+	if digits == "" || strings.ContainsAny(digits[:1], "+-_") ||
+		strings.HasSuffix(digits, "_") || strings.Contains(digits, "__") {
+		return 0, fmt.Errorf("malformed integer %q", literal)
+	}
+	clean := strings.ReplaceAll(digits, "_", "")
+	if base == 10 {
+		clean = literal[:len(literal)-len(digits)] + clean
+	}
+	i, err := strconv.ParseInt(clean, base, 64)
+	if err != nil {
+		return 0, fmt.Errorf("malformed integer %q", literal)
+	}
+	return i, nil
+}
+
+// closingQuote returns the index of the quote ending the basic string that
+// starts s, or -1.
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// quoteTOML renders s as a TOML basic string, escaping control characters
+// with the \u form TOML accepts.
+func quoteTOML(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case unicode.IsControl(r):
+			fmt.Fprintf(&b, `\u%04X`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
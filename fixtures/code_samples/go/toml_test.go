@@ -0,0 +1,83 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTOMLRoundTrip(t *testing.T) {
+	cfgs := []*Config{
+		NewConfig("port", WithValue(8080)),
+		NewConfig("offset", WithValue(-3), WithDescription("shift \"left\"\n\tby three")),
+		NewConfig("", WithValue(0)),
+	}
+	for _, want := range cfgs {
+		var b strings.Builder
+		if err := SaveTOML(&b, want); err != nil {
+			t.Fatalf("SaveTOML(%v): %v", want, err)
+		}
+		got, err := LoadTOML(strings.NewReader(b.String()))
+		if err != nil {
+			t.Fatalf("LoadTOML(%q): %v", b.String(), err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("round trip of %v via %q = %v", want, b.String(), got)
+		}
+	}
+}
+
+func TestLoadTOMLIntegers(t *testing.T) {
+	tests := []struct {
+		literal string
+		want    int64
+	}{
+		{"0", 0},
+		{"-0", 0},
+		{"+17", 17},
+		{"-42", -42},
+		{"1_000_000", 1000000},
+		{"0xDEAD_beef", 0xdeadbeef},
+		{"0o755", 0o755},
+		{"0b1010", 10},
+		{"12 # trailing comment", 12},
+	}
+	for _, tt := range tests {
+		cfg, err := LoadTOML(strings.NewReader("name = \"n\"\nvalue = " + tt.literal + "\n"))
+		if err != nil {
+			t.Errorf("value = %s: %v", tt.literal, err)
+			continue
+		}
+		if cfg.Value != tt.want {
+			t.Errorf("value = %s: got %v, want %d", tt.literal, cfg.Value, tt.want)
+		}
+	}
+}
+
+func TestLoadTOMLMalformed(t *testing.T) {
+	tests := []string{
+		"name = \"n\"\nvalue = 017\n",
+		"name = \"n\"\nvalue = 00\n",
+		"name = \"n\"\nvalue = 1__000\n",
+		"name = \"n\"\nvalue = _1\n",
+		"name = \"n\"\nvalue = 1_\n",
+		"name = \"n\"\nvalue = --1\n",
+		"name = \"n\"\nvalue = -0x10\n",
+		"name = \"n\"\nvalue = 0x\n",
+		"name = \"n\"\nvalue = 0X10\n",
+		"name = \"n\"\nvalue = 1.5\n",
+		"name = \"n\"\nvalue = true\n",
+		"name = \"n\"\nvalue = 99999999999999999999\n",
+		"name = \"unterminated\n",
+		"name = 'unterminated\n",
+		"name = \"n\" trailing\n",
+		"name = \"n\"\nname = \"m\"\n",
+		"[table]\n",
+		"name\n",
+		"= 1\n",
+	}
+	for _, doc := range tests {
+		if cfg, err := LoadTOML(strings.NewReader(doc)); err == nil {
+			t.Errorf("LoadTOML(%q) = %v, want error", doc, cfg)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// TypedConfig is a Config whose Value has a static type, such as
+// TypedConfig[string] or TypedConfig[time.Duration], so callers get type
+// safety instead of runtime assertions on Config.Value.
+//
+// Config itself stays the dynamically typed form every loader and
+// encoder works with; convert with Untyped when needed.
+type TypedConfig[T any] struct {
+	Name        string
+	Value       T
+	Description string
+}
+
+// Int64Config is the typed counterpart of the int64 configs NewConfig
+// builds.
+type Int64Config = TypedConfig[int64]
+
+// NewTypedConfig creates a new TypedConfig.
+func NewTypedConfig[T any](name string, value T) *TypedConfig[T] {
+	return &TypedConfig[T]{Name: name, Value: value}
+}
+
+// String formats the config as "name: value", rendering the value with
+// fmt.Sprint. A nil config formats as "<nil config>".
+func (c *TypedConfig[T]) String() string {
+	if c == nil {
+		return "<nil config>"
+	}
+	return fmt.Sprintf("%s: %s", c.Name, fmt.Sprint(c.Value))
+}
+
+// Display returns a formatted string. It is equivalent to String.
+func (c *TypedConfig[T]) Display() string {
+	return c.String()
+}
+
+// Untyped returns the equivalent dynamically typed Config. Values are
+// converted by kind to the int64, string, bool or float64 the rest of the
+// package handles, so a time.Duration becomes its int64 nanoseconds and a
+// string-based enum its string. Other kinds, and unsigned values above
+// math.MaxInt64, are errors wrapping ErrInvalidValue.
+func (c *TypedConfig[T]) Untyped() (*Config, error) {
+	v := reflect.ValueOf(c.Value)
+	var value any
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value = v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("%w for %q: %v overflows int64", ErrInvalidValue, c.Name, c.Value)
+		}
+		value = int64(v.Uint())
+	case reflect.String:
+		value = v.String()
+	case reflect.Bool:
+		value = v.Bool()
+	case reflect.Float32, reflect.Float64:
+		value = v.Float()
+	default:
+		return nil, fmt.Errorf("%w for %q: unsupported type %T", ErrInvalidValue, c.Name, c.Value)
+	}
+	return &Config{Name: c.Name, Value: value, Description: c.Description, present: []string{"Value"}}, nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// level is a custom enum type for TypedConfig tests.
+type level uint8
+
+const (
+	levelDebug level = iota
+	levelInfo
+)
+
+func (l level) String() string {
+	if l == levelDebug {
+		return "debug"
+	}
+	return "info"
+}
+
+func TestTypedConfigInt64(t *testing.T) {
+	var cfg *Int64Config = NewTypedConfig("port", int64(8080))
+	if got := cfg.String(); got != "port: 8080" {
+		t.Errorf("String() = %q, want %q", got, "port: 8080")
+	}
+	untyped, err := cfg.Untyped()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !untyped.Equal(NewConfig("port", WithValue(8080))) {
+		t.Errorf("Untyped() = %v", untyped)
+	}
+}
+
+func TestTypedConfigString(t *testing.T) {
+	cfg := NewTypedConfig("host", "localhost")
+	if got := cfg.Display(); got != "host: localhost" {
+		t.Errorf("Display() = %q, want %q", got, "host: localhost")
+	}
+	untyped, err := cfg.Untyped()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := untyped.AsString(); !ok || got != "localhost" {
+		t.Errorf("Untyped().AsString() = %q, %v", got, ok)
+	}
+}
+
+func TestTypedConfigCustomType(t *testing.T) {
+	cfg := NewTypedConfig("log", levelInfo)
+	if got := cfg.String(); got != "log: info" {
+		t.Errorf("String() = %q, want %q", got, "log: info")
+	}
+	untyped, err := cfg.Untyped()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if untyped.Value != int64(levelInfo) {
+		t.Errorf("Untyped().Value = %#v, want int64(%d)", untyped.Value, levelInfo)
+	}
+
+	timeout, err := NewTypedConfig("timeout", 2*time.Second).Untyped()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if timeout.Value != int64(2*time.Second) {
+		t.Errorf("Untyped().Value = %#v, want %d", timeout.Value, int64(2*time.Second))
+	}
+}
+
+func TestTypedConfigUntypedUnsupported(t *testing.T) {
+	if cfg, err := NewTypedConfig("tags", []string{"a"}).Untyped(); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("Untyped() of a slice = %v, %v; want ErrInvalidValue", cfg, err)
+	}
+	if cfg, err := NewTypedConfig("big", uint64(math.MaxUint64)).Untyped(); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("Untyped() of MaxUint64 = %v, %v; want ErrInvalidValue", cfg, err)
+	}
+}
+
+func TestTypedConfigNilString(t *testing.T) {
+	var cfg *TypedConfig[string]
+	if got := cfg.String(); got != "<nil config>" {
+		t.Errorf("nil String() = %q", got)
+	}
+}
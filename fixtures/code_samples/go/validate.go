@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ConfigValidator checks one rule against a Config.
+type ConfigValidator func(*Config) error
+
+// defaultValidators run before any validators attached to a Config.
+var defaultValidators = []ConfigValidator{NonEmptyName}
+
+// WithValidators attaches additional validators, run by Validate after
+// the defaults in the order given.
+func WithValidators(validators ...ConfigValidator) Option {
+	return func(c *Config) { c.validators = append(c.validators, validators...) }
+}
+
+// Validate runs the default validators followed by the attached ones, in
+// order, and joins every failure into a single error.
+func (c *Config) Validate() error {
+	var errs []error
+	for _, validate := range defaultValidators {
+		errs = append(errs, validate(c))
+	}
+	for _, validate := range c.validators {
+		errs = append(errs, validate(c))
+	}
+	return errors.Join(errs...)
+}
+
+// NonEmptyName rejects a Config without a Name.
+func NonEmptyName(c *Config) error {
+	if c.Name == "" {
+		return ErrEmptyName
+	}
+	return nil
+}
+
+// InRange returns a validator requiring an int64 Value within [min, max].
+func InRange(min, max int64) ConfigValidator {
+	return func(c *Config) error {
+		v, err := c.int64Value()
+		if err != nil {
+			return err
+		}
+		if v < min || v > max {
+			return fmt.Errorf("%w for %q: %d outside [%d, %d]", ErrInvalidValue, c.Name, v, min, max)
+		}
+		return nil
+	}
+}
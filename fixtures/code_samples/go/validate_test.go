@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateDefaultRejectsEmptyName(t *testing.T) {
+	if err := NewConfig("").Validate(); !errors.Is(err, ErrEmptyName) {
+		t.Errorf("Validate() of an unnamed config = %v, want ErrEmptyName", err)
+	}
+	if err := NewConfig("db").Validate(); err != nil {
+		t.Errorf("Validate() of a named config = %v, want nil", err)
+	}
+}
+
+func TestValidateCustomRangeValidator(t *testing.T) {
+	tests := []struct {
+		value   int64
+		wantErr bool
+	}{
+		{0, false},
+		{100, false},
+		{101, true},
+		{-1, true},
+	}
+	for _, tt := range tests {
+		cfg := NewConfig("pct", WithValue(tt.value), WithValidators(InRange(0, 100)))
+		err := cfg.Validate()
+		if tt.wantErr != (err != nil) {
+			t.Errorf("Validate() with Value %d = %v, want error: %v", tt.value, err, tt.wantErr)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("Validate() with Value %d = %v, want ErrInvalidValue", tt.value, err)
+		}
+	}
+}
+
+func TestValidateJoinsFailuresInOrder(t *testing.T) {
+	var order []string
+	rule := func(name string) ConfigValidator {
+		return func(*Config) error {
+			order = append(order, name)
+			return errors.New(name)
+		}
+	}
+	err := NewConfig("", WithValidators(rule("first"), rule("second"))).Validate()
+	if !errors.Is(err, ErrEmptyName) {
+		t.Errorf("Validate() = %v, want it to include ErrEmptyName", err)
+	}
+	if len(order) != 2 || order[0] != "first" || order[1] != "second" {
+		t.Errorf("validators ran in order %v, want [first second]", order)
+	}
+	if got := len(err.(interface{ Unwrap() []error }).Unwrap()); got != 3 {
+		t.Errorf("Validate() joined %d errors, want 3: %v", got, err)
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VerboseOption configures DisplayVerbose.
+type VerboseOption func(*verboseOptions)
+
+type verboseOptions struct {
+	trailingNewline bool
+}
+
+// WithTrailingNewline terminates the last line of DisplayVerbose output.
+func WithTrailingNewline() VerboseOption {
+	return func(o *verboseOptions) { o.trailingNewline = true }
+}
+
+// DisplayVerbose formats one field per line with values aligned after the
+// longest key:
+//
+//	Name:  test
+//	Value: 42
+//
+// Value is formatted and sensitive fields redacted as in String; optional
+// fields that are unset are left out.
+func (c *Config) DisplayVerbose(opts ...VerboseOption) string {
+	var o verboseOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	fields := c.verboseFields()
+	width := keyWidth(fields)
+	lines := make([]string, len(fields))
+	for i, f := range fields {
+		lines[i] = c.verboseLine(f, width)
+	}
+	if o.trailingNewline {
+		return strings.Join(lines, "\n") + "\n"
+	}
+	return strings.Join(lines, "\n")
+}
+
+// verboseFields returns the fields DisplayVerbose shows, leaving out unset
+// optional ones.
+func (c *Config) verboseFields() []configField {
+	var shown []configField
+	for _, f := range c.fields() {
+		if f.name == "Description" && f.value == "" {
+			continue
+		}
+		shown = append(shown, f)
+	}
+	return shown
+}
+
+// keyWidth returns the length of the longest field name.
+func keyWidth(fields []configField) int {
+	width := 0
+	for _, f := range fields {
+		width = max(width, len(f.name))
+	}
+	return width
+}
+
+// verboseLine formats f as "Key: value" with the value starting after a
+// key padded to width.
+func (c *Config) verboseLine(f configField, width int) string {
+	text := fmt.Sprint(f.value)
+	switch {
+	case c.isSensitive(f.name):
+		text = redactedText
+	case f.name == "Value":
+		text = formatValue(f.value)
+	}
+	return f.name + ": " + strings.Repeat(" ", width-len(f.name)) + text
+}
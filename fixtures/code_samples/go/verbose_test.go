@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// checkGolden compares got with testdata/name, rewriting the file instead
+// when the test runs with -update.
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Errorf("output does not match %s:\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+func TestDisplayVerboseGolden(t *testing.T) {
+	cfgs := []*Config{
+		NewConfig("port", WithValue(8080), WithDescription("HTTP listen port")),
+		NewStringConfig("host", "localhost"),
+		NewConfig("token", WithValue(42), WithSensitiveFields("Value")),
+	}
+	var got string
+	for _, cfg := range cfgs {
+		got += cfg.DisplayVerbose(WithTrailingNewline()) + "\n"
+	}
+	checkGolden(t, "verbose.golden", got)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// WatchOption configures WatchFile.
+type WatchOption func(*watchOptions)
+
+type watchOptions struct {
+	debounce time.Duration
+	poll     time.Duration
+	mapOpts  []MapOption
+}
+
+// WithDebounce sets how long a file must stay unchanged before WatchFile
+// reloads it, so an editor's back-to-back writes yield one reload.
+// The default is 200ms; negative durations mean no debounce.
+func WithDebounce(d time.Duration) WatchOption {
+	return func(o *watchOptions) { o.debounce = max(d, 0) }
+}
+
+// WithPollInterval sets how often WatchFile checks the file. The default
+// is 100ms, which is kept if d is not positive.
+func WithPollInterval(d time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		if d > 0 {
+			o.poll = d
+		}
+	}
+}
+
+// WithWatchLoadOptions passes opts to the loader WatchFile parses the file
+// with, as for FileSource.
+func WithWatchLoadOptions(opts ...MapOption) WatchOption {
+	return func(o *watchOptions) { o.mapOpts = append(o.mapOpts, opts...) }
+}
+
+// WatchFile reloads the config file at path whenever it changes, sending
+// each new Config, or the error from parsing it, on the returned channels.
+// The file is parsed as FileSource does, by extension; the contents at
+// the time of the call are not sent, but any change after WatchFile
+// returns is.
+//
+// Changes are detected by polling the file's identity, size and
+// modification time rather than through OS notifications, which needs no
+// dependencies and survives editors that save by writing a new file and
+// renaming it over the old one: while the file is missing the watcher
+// waits for it to reappear. Changes closer together than the debounce
+// window are coalesced.
+//
+// Both channels are closed once ctx is done.
+func WatchFile(ctx context.Context, path string, opts ...WatchOption) (<-chan *Config, <-chan error) {
+	o := watchOptions{debounce: 200 * time.Millisecond, poll: 100 * time.Millisecond}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	cfgs := make(chan *Config)
+	errs := make(chan error)
+	last := statFile(path)
+	go func() {
+		defer close(cfgs)
+		defer close(errs)
+		ticker := time.NewTicker(o.poll)
+		defer ticker.Stop()
+
+		var pending bool
+		var changedAt time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				cur := statFile(path)
+				if fileChanged(last, cur) {
+					last, pending, changedAt = cur, true, now
+				}
+				if !pending || cur == nil || now.Sub(changedAt) < o.debounce {
+					continue
+				}
+				pending = false
+				cfg, err := FileSource{Path: path, Options: o.mapOpts}.Load()
+				if err != nil {
+					select {
+					case errs <- err:
+					case <-ctx.Done():
+						return
+					}
+					continue
+				}
+				select {
+				case cfgs <- cfg:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return cfgs, errs
+}
+
+// statFile returns the file's info, or nil if it cannot be read.
+func statFile(path string) os.FileInfo {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+	return info
+}
+
+// fileChanged reports whether the file appeared, disappeared, was
+// replaced or was modified between two stats.
+func fileChanged(before, after os.FileInfo) bool {
+	if before == nil || after == nil {
+		return (before == nil) != (after == nil)
+	}
+	return !os.SameFile(before, after) ||
+		before.Size() != after.Size() ||
+		!before.ModTime().Equal(after.ModTime())
+}
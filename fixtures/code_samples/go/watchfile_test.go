@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fastWatch shortens WatchFile's timings so tests run quickly.
+var fastWatch = []WatchOption{WithPollInterval(5 * time.Millisecond), WithDebounce(20 * time.Millisecond)}
+
+// receive waits for the next config or error from WatchFile.
+func receive(t *testing.T, cfgs <-chan *Config, errs <-chan error) (*Config, error) {
+	t.Helper()
+	select {
+	case cfg := <-cfgs:
+		return cfg, nil
+	case err := <-errs:
+		return nil, err
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for WatchFile")
+		return nil, nil
+	}
+}
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWatchFileDeliversWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.json")
+	writeFile(t, path, `{"name":"app","value":1}`)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cfgs, errs := WatchFile(ctx, path, fastWatch...)
+
+	// Two quick writes, as editors make, are debounced into one reload.
+	writeFile(t, path, `{"name":"app","value":2}`)
+	writeFile(t, path, `{"name":"app","value":3,"description":"x"}`)
+	cfg, err := receive(t, cfgs, errs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := NewConfig("app", WithValue(3), WithDescription("x")); !cfg.Equal(want) {
+		t.Errorf("reloaded %v, want %v", cfg, want)
+	}
+
+	// Saving via a temp file renamed over the original keeps the watch.
+	tmp := path + ".tmp"
+	writeFile(t, tmp, `{"name":"app","value":4}`)
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = receive(t, cfgs, errs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Value != int64(4) {
+		t.Errorf("after rename, Value = %v, want 4", cfg.Value)
+	}
+
+	writeFile(t, path, `{"value":5}`)
+	if _, err := receive(t, cfgs, errs); err == nil {
+		t.Error("invalid file reloaded without an error")
+	}
+
+	cancel()
+	for range cfgs {
+	}
+	for range errs {
+	}
+}
+
+func TestWatchFileNonPositiveOptions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.json")
+	ctx, cancel := context.WithCancel(context.Background())
+	cfgs, errs := WatchFile(ctx, path, WithPollInterval(0), WithPollInterval(-time.Second), WithDebounce(-time.Second))
+	writeFile(t, path, `{"name":"app","value":1}`)
+	if _, err := receive(t, cfgs, errs); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	for range cfgs {
+	}
+}
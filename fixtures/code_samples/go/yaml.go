@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// LoadYAML reads a Config from a YAML document with top-level name: and
+// value: keys.
+//
+// Config is a flat mapping, so only the block-mapping subset of YAML is
+// understood: one "key: scalar" pair per line, with comments, blank lines
+// and a leading "---" allowed. Nested or flow collections are rejected. A
+// null value (null, ~ or nothing) counts as 0 for value and "" for
+// description.
+func LoadYAML(r io.Reader, opts ...MapOption) (*Config, error) {
+	fields := make(map[string]any)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" || trimmed == "---" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if text != trimmed {
+			return nil, fmt.Errorf("yaml: line %d: nested values are not supported", line)
+		}
+		key, raw, ok := strings.Cut(text, ":")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("yaml: line %d: expected \"key: value\"", line)
+		}
+		if _, dup := fields[key]; dup {
+			return nil, fmt.Errorf("yaml: line %d: duplicate key %q", line, key)
+		}
+		value, err := parseYAMLScalar(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("yaml: line %d: %w", line, err)
+		}
+		fields[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("yaml: %w", err)
+	}
+	return FromMap(fields, opts...)
+}
+
+// SaveYAML writes cfg as YAML with keys in a fixed order (name, value,
+// then description if set) so the output diffs cleanly.
+func SaveYAML(w io.Writer, cfg *Config) error {
+	value, err := cfg.int64Value()
+	if err != nil {
+		return fmt.Errorf("yaml: %w", err)
+	}
+	if _, err := fmt.Fprintf(w, "name: %s\nvalue: %d\n", strconv.Quote(cfg.Name), value); err != nil {
+		return err
+	}
+	if cfg.Description == "" {
+		return nil
+	}
+	_, err = fmt.Fprintf(w, "description: %s\n", strconv.Quote(cfg.Description))
+	return err
+}
+
+// parseYAMLScalar unquotes a scalar and strips a trailing comment. null,
+// ~ and an empty value are YAML's null and decode to nil.
+func parseYAMLScalar(raw string) (any, error) {
+	var value, rest string
+	switch {
+	case strings.HasPrefix(raw, `"`):
+		end := closingQuote(raw)
+		if end < 0 {
+			return nil, fmt.Errorf("malformed double-quoted string %s", raw)
+		}
+		s, err := strconv.Unquote(raw[:end+1])
+		if err != nil {
+			return nil, fmt.Errorf("malformed double-quoted string %s", raw[:end+1])
+		}
+		value, rest = s, raw[end+1:]
+	case strings.HasPrefix(raw, "'"):
+		end := closingSingleQuote(raw)
+		if end < 0 {
+			return nil, fmt.Errorf("malformed single-quoted string %s", raw)
+		}
+		value, rest = strings.ReplaceAll(raw[1:end], "''", "'"), raw[end+1:]
+	case raw != "" && strings.ContainsRune("[{|>&*!", rune(raw[0])):
+		return nil, fmt.Errorf("unsupported value %s", raw)
+	default:
+		if strings.HasPrefix(raw, "#") {
+			raw = ""
+		} else if i := strings.Index(raw, " #"); i >= 0 {
+			raw = strings.TrimSpace(raw[:i])
+		}
+		switch raw {
+		case "", "~", "null", "Null", "NULL":
+			return nil, nil
+		}
+		return raw, nil
+	}
+	// A comment must be separated from the string by whitespace.
+	if comment := strings.TrimSpace(rest); comment != "" && (comment == rest || !strings.HasPrefix(comment, "#")) {
+		return nil, fmt.Errorf("unexpected %q after quoted string", comment)
+	}
+	return value, nil
+}
+
+// closingSingleQuote returns the index of the quote ending the
+// single-quoted string that starts s, or -1. A doubled quote is an escaped
+// one.
+func closingSingleQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		if s[i] != '\'' {
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == '\'' {
+			i++
+			continue
+		}
+		return i
+	}
+	return -1
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestYAMLRoundTrip(t *testing.T) {
+	cfgs := []*Config{
+		NewConfig("port", WithValue(8080)),
+		NewConfig("", WithValue(-3)),
+		NewConfig("it's # not a comment", WithValue(1), WithDescription("line\nbreak \"quoted\"")),
+	}
+	for _, want := range cfgs {
+		var b strings.Builder
+		if err := SaveYAML(&b, want); err != nil {
+			t.Fatalf("SaveYAML(%v): %v", want, err)
+		}
+		got, err := LoadYAML(strings.NewReader(b.String()))
+		if err != nil {
+			t.Fatalf("LoadYAML(%q): %v", b.String(), err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("round trip of %v via %q = %v", want, b.String(), got)
+		}
+	}
+}
+
+func TestLoadYAML(t *testing.T) {
+	tests := []struct {
+		doc  string
+		want *Config
+	}{
+		{"name: db\nvalue: 5432\n", NewConfig("db", WithValue(5432))},
+		{"---\n# comment\n\nname: db # trailing\nvalue: 1\n", NewConfig("db", WithValue(1))},
+		{"name: \"db\" # trailing\nvalue: '2' # trailing\n", NewConfig("db", WithValue(2))},
+		{"name: 'it''s'\nvalue: 3\n", NewConfig("it's", WithValue(3))},
+		{"name: db\nvalue: null\n", NewConfig("db", WithValue(0))},
+		{"name: db\nvalue: ~\ndescription: ~\n", NewConfig("db", WithValue(0))},
+		{"name: db\nvalue:\n", NewConfig("db", WithValue(0))},
+		{"name: db\n", NewConfig("db", WithValue(0))},
+	}
+	for _, tt := range tests {
+		got, err := LoadYAML(strings.NewReader(tt.doc))
+		if err != nil {
+			t.Errorf("LoadYAML(%q): %v", tt.doc, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("LoadYAML(%q) = %v, want %v", tt.doc, got, tt.want)
+		}
+	}
+}
+
+func TestLoadYAMLMalformed(t *testing.T) {
+	tests := []string{
+		"name: db\nvalue: forty\n",
+		"name: db\nvalue: 1.5\n",
+		"name: \"db\nvalue: 1\n",
+		"name: 'db\nvalue: 1\n",
+		"name: \"db\"x\n",
+		"name: \"db\"# no space\n",
+		"name: db\n  nested: 1\n",
+		"name: [a, b]\n",
+		"value: {a: 1}\n",
+		"name: db\nname: again\n",
+		"just text\n",
+		": 1\n",
+		"value: 1\n",
+	}
+	for _, doc := range tests {
+		if cfg, err := LoadYAML(strings.NewReader(doc)); err == nil {
+			t.Errorf("LoadYAML(%q) = %v, want error", doc, cfg)
+		}
+	}
+}